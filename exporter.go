@@ -10,6 +10,7 @@ import (
 	"github.com/dmartinol/openshift-topology-exporter/pkg/builder"
 	config "github.com/dmartinol/openshift-topology-exporter/pkg/config"
 	log "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/model"
 	t "github.com/dmartinol/openshift-topology-exporter/pkg/transformer"
 
 	"k8s.io/client-go/rest"
@@ -34,17 +35,11 @@ func start() error {
 	formatter := t.NewFormatterForConfig(exporterConfig)
 	transformer := t.NewTransformer(formatter)
 
-	config, err := connectCluster()
-	if err != nil {
-		return err
-	}
-	log.Info("Cluster connected")
-
-	topology, err := builder.NewModelBuilder(exporterConfig).BuildForConfig(config)
+	topology, err := buildTopology()
 	if err != nil {
 		return err
 	}
-	output, err := transformer.Transform(*topology)
+	output, err := transformer.Transform(topology)
 	if err != nil {
 		return err
 	}
@@ -52,6 +47,21 @@ func start() error {
 	return err
 }
 
+func buildTopology() (*model.TopologyModel, error) {
+	if exporterConfig.Manifests != "" {
+		log.Infof("Reading manifests from %s", exporterConfig.Manifests)
+		return builder.NewModelBuilder(exporterConfig).BuildForManifests(exporterConfig.Manifests)
+	}
+
+	config, err := connectCluster()
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Cluster connected")
+
+	return builder.NewModelBuilder(exporterConfig).BuildForConfig(config)
+}
+
 func connectCluster() (*rest.Config, error) {
 	var kubeconfig *string
 
@@ -0,0 +1,172 @@
+package builder
+
+import (
+	"strings"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/model/knative"
+	"knative.dev/serving/pkg/apis/serving"
+)
+
+// replicaKinds are the intermediate controllers aggregated, with their Pods, into the workload that manages them
+var replicaKinds = []string{model.ReplicaSet{}.Kind(), model.ReplicationController{}.Kind(), model.Job{}.Kind()}
+
+// workloadKinds are the kinds aggregating their Pods
+var workloadKinds = []string{model.Deployment{}.Kind(), model.DeploymentConfig{}.Kind(), model.StatefulSet{}.Kind(),
+	model.DaemonSet{}.Kind(), model.CronJob{}.Kind(), model.Job{}.Kind(), model.ReplicaSet{}.Kind(),
+	model.ReplicationController{}.Kind()}
+
+// applicationKinds are the kinds grouped into applications, in addition to the standalone Pods
+var applicationKinds = append(append([]string{}, workloadKinds...), knative.Service{}.Kind())
+
+// aggregation collects the resources replaced by a workload
+type aggregation struct {
+	workload model.Resource
+	pods     []model.Pod
+	members  []model.Resource
+}
+
+// aggregateWorkloads replaces the Pods, and the ReplicaSets, ReplicationControllers and Jobs managed by another
+// controller, with the workload that runs them, labelled with the number of ready Pods and their usage. The connections
+// of the replaced resources, like the Services selecting the Pods, are moved to the workload.
+// When the members are not merged, only the workloads are labelled
+func (builder *ModelBuilder) aggregateWorkloads(namespaceModel *model.NamespaceModel, mergeMembers bool) {
+	aggregations := make(map[string]*aggregation)
+	keys := make([]string, 0)
+	resources := namespaceModel.ResourcesByKind(model.Pod{}.Kind())
+	for _, kind := range workloadKinds {
+		resources = append(resources, namespaceModel.ResourcesByKind(kind)...)
+	}
+	for _, resource := range resources {
+		workload := aggregatingWorkloadOf(namespaceModel, resource)
+		isWorkload := isSameResource(workload, resource)
+		pod, isPod := resource.(model.Pod)
+		if isPod && isWorkload {
+			// Standalone Pod
+			continue
+		}
+		key := workload.Kind() + "/" + workload.Id()
+		if _, ok := aggregations[key]; !ok {
+			aggregations[key] = &aggregation{workload: workload}
+			keys = append(keys, key)
+		}
+		if isPod {
+			aggregations[key].pods = append(aggregations[key].pods, pod)
+		}
+		if !isWorkload {
+			aggregations[key].members = append(aggregations[key].members, resource)
+		}
+	}
+
+	for _, key := range keys {
+		aggregation := aggregations[key]
+		logger.Debugf("Aggregating %d resources into %s of kind %s", len(aggregation.members),
+			aggregation.workload.Name(), aggregation.workload.Kind())
+		workload := model.NewWorkload(aggregation.workload, aggregation.pods)
+		namespaceModel.MergeResource(aggregation.workload, workload)
+		if !mergeMembers {
+			continue
+		}
+		for _, member := range aggregation.members {
+			namespaceModel.MergeResource(member, workload)
+		}
+	}
+}
+
+// aggregatingWorkloadOf walks up the controllers of the given Pod, ReplicaSet, ReplicationController or Job until
+// a controller of another kind, like a Deployment, and returns the resource itself when it has no such controller
+func aggregatingWorkloadOf(namespaceModel *model.NamespaceModel, resource model.Resource) model.Resource {
+	workload := resource
+	for depth := 0; depth < maxOwnerDepth; depth++ {
+		if strings.Compare(workload.Kind(), model.Pod{}.Kind()) != 0 && !isReplicaKind(workload.Kind()) {
+			break
+		}
+		controller := controllerOf(namespaceModel, workload)
+		if controller == nil {
+			break
+		}
+		workload = controller
+	}
+	return workload
+}
+
+func isSameResource(resource model.Resource, other model.Resource) bool {
+	return strings.Compare(resource.Kind(), other.Kind()) == 0 && strings.Compare(resource.Id(), other.Id()) == 0
+}
+
+func isReplicaKind(kind string) bool {
+	for _, replicaKind := range replicaKinds {
+		if strings.Compare(kind, replicaKind) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregateApplications replaces the workloads, the Knative Services and the standalone Pods labelled with the
+// model.PartOfLabel with their application, labelled with the number of ready Pods of all its workloads. The Pods of
+// the Knative Services are the ones of the workloads of their Revisions
+func (builder *ModelBuilder) aggregateApplications(namespaceModel *model.NamespaceModel) {
+	applications := make(map[string]*model.Application)
+	members := make(map[string][]model.Resource)
+	names := make([]string, 0)
+	resources := namespaceModel.ResourcesByKind(model.Pod{}.Kind())
+	for _, kind := range applicationKinds {
+		resources = append(resources, namespaceModel.ResourcesByKind(kind)...)
+	}
+	knativeServices := make(map[string]string)
+	for _, resource := range namespaceModel.ResourcesByKind(knative.Service{}.Kind()) {
+		if knativeService, ok := resource.(knative.Service); ok {
+			knativeServices[resource.Name()] = knativeService.Labels()[model.PartOfLabel]
+		}
+	}
+	for _, resource := range resources {
+		labeled, ok := resource.(model.Labeled)
+		if !ok {
+			continue
+		}
+		name := labeled.Labels()[model.PartOfLabel]
+		if name == "" {
+			continue
+		}
+		if _, ok := applications[name]; !ok {
+			applications[name] = &model.Application{PartOf: name}
+			names = append(names, name)
+		}
+		switch resource := resource.(type) {
+		case model.Workload:
+			// Already counted with its Knative Service
+			if partOf, ok := knativeServices[resource.Labels()[serving.ServiceLabelKey]]; !ok || partOf != name {
+				applications[name].Add(resource)
+			}
+		case model.Pod:
+			applications[name].Add(model.NewWorkload(resource, []model.Pod{resource}))
+		case knative.Service:
+			for _, workload := range revisionWorkloadsOf(namespaceModel, resource) {
+				applications[name].Add(workload)
+			}
+		}
+		members[name] = append(members[name], resource)
+	}
+
+	for _, name := range names {
+		logger.Debugf("Aggregating %d workloads into application %s", len(members[name]), name)
+		for _, member := range members[name] {
+			namespaceModel.MergeResource(member, *applications[name])
+		}
+	}
+}
+
+// revisionWorkloadsOf returns the aggregated workloads running the Revisions of the given Knative Service
+func revisionWorkloadsOf(namespaceModel *model.NamespaceModel, knativeService knative.Service) []model.Workload {
+	workloads := make([]model.Workload, 0)
+	for _, kind := range workloadKinds {
+		for _, resource := range namespaceModel.ResourcesByKind(kind) {
+			if workload, ok := resource.(model.Workload); ok && workload.Labels()[serving.ServiceLabelKey] == knativeService.Name() {
+				workloads = append(workloads, workload)
+			}
+		}
+	}
+	return workloads
+}
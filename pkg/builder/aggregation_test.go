@@ -0,0 +1,55 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/dmartinol/openshift-topology-exporter/pkg/config"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/serving/pkg/apis/serving"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func TestAggregation(t *testing.T) {
+	partOf := map[string]string{model.PartOfLabel: "shop"}
+	replicas := int32(3)
+	api := &appsv1.Deployment{ObjectMeta: objectMeta("api", "d1", partOf), Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	apiReplicaSet := &appsv1.ReplicaSet{ObjectMeta: objectMeta("api-1", "r1", nil, controllerRef("apps/v1", "Deployment", "api", "d1"))}
+	apiReplicaSetRef := controllerRef("apps/v1", "ReplicaSet", "api-1", "r1")
+	service := &corev1.Service{ObjectMeta: objectMeta("api", "", nil), Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "api"}}}
+	knativeService := &servingv1.Service{ObjectMeta: objectMeta("hello", "ks1", partOf)}
+	hello := &appsv1.Deployment{ObjectMeta: objectMeta("hello-1-deployment", "d2", map[string]string{serving.ServiceLabelKey: "hello"})}
+	objects := []runtime.Object{api, apiReplicaSet, service, knativeService, hello,
+		runningPod("api-1-a", map[string]string{"app": "api"}, true, apiReplicaSetRef),
+		runningPod("api-1-b", map[string]string{"app": "api"}, true, apiReplicaSetRef),
+		runningPod("api-1-c", map[string]string{"app": "api"}, false, apiReplicaSetRef),
+		runningPod("hello-1-a", nil, true, controllerRef("apps/v1", "Deployment", "hello-1-deployment", "d2")),
+		runningPod("standalone", partOf, true),
+	}
+
+	tests := []struct {
+		detailLevel string
+		kind        string
+		id          string
+		wantLabel   string
+		want        []string
+		unwanted    []string
+	}{
+		{config.PodsDetailLevel, "Pod", "pod api-1-a", "api-1-a", []string{"svc api -> pod api-1-a"}, nil},
+		{config.WorkloadsDetailLevel, "Deployment", "deployment api", "api (2/3)",
+			[]string{"svc api -> deployment api"}, []string{"svc api -> pod api-1-a", "deployment api -> rs api-1 (owns)"}},
+		{config.ApplicationsDetailLevel, "Application", "app shop", "shop (4/5)",
+			[]string{"svc api -> app shop"}, []string{"svc api -> deployment api"}},
+	}
+	for _, test := range tests {
+		t.Run(test.detailLevel, func(t *testing.T) {
+			namespaceModel := buildTestNamespace(t, config.ExporterConfig{KNative: true, DetailLevel: test.detailLevel}, objects...)
+			if label := labelOf(namespaceModel, test.kind, test.id); label != test.wantLabel {
+				t.Errorf("label of %s = %q, want %q", test.id, label, test.wantLabel)
+			}
+			assertConnections(t, namespaceModel, test.want, test.unwanted)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package builder
+
+import (
+	"strconv"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	buildv1 "github.com/openshift/api/build/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// addBuilds adds the ImageStreams with their tags, the BuildConfigs and their latest Build. The Builds of the
+// previous versions are skipped
+func (builder *ModelBuilder) addBuilds(namespaceModel *model.NamespaceModel, objectsByKind map[schema.GroupVersionKind][]runtime.Object) {
+	namespace := namespaceModel.Name()
+	logger.Infof("=== %s/ImageStreams ===", namespace)
+	for _, object := range objectsByKind[source.ImageStreamKind] {
+		imageStream := *object.(*imagev1.ImageStream)
+		logger.Debugf("Found %s/%s", imageStream.Kind, imageStream.Name)
+		resource := model.ImageStream{Delegate: imageStream}
+		namespaceModel.AddResource(resource)
+		for _, tag := range resource.Tags() {
+			namespaceModel.AddResource(tag)
+		}
+	}
+
+	logger.Infof("=== %s/BuildConfigs ===", namespace)
+	for _, object := range objectsByKind[source.BuildConfigKind] {
+		buildConfig := *object.(*buildv1.BuildConfig)
+		logger.Debugf("Found %s/%s", buildConfig.Kind, buildConfig.Name)
+		namespaceModel.AddResource(model.BuildConfig{Delegate: buildConfig})
+	}
+
+	logger.Infof("=== %s/Builds ===", namespace)
+	latestBuilds := make(map[string]buildv1.Build)
+	for _, object := range objectsByKind[source.BuildKind] {
+		build := *object.(*buildv1.Build)
+		logger.Debugf("Found %s/%s", build.Kind, build.Name)
+		config, ok := build.Annotations[buildv1.BuildConfigAnnotation]
+		if !ok {
+			namespaceModel.AddResource(model.Build{Delegate: build})
+			continue
+		}
+		if latest, ok := latestBuilds[config]; !ok || buildNumber(build) > buildNumber(latest) {
+			latestBuilds[config] = build
+		}
+	}
+	for _, build := range latestBuilds {
+		namespaceModel.AddResource(model.Build{Delegate: build})
+	}
+}
+
+func buildNumber(build buildv1.Build) int64 {
+	number, err := strconv.ParseInt(build.Annotations[buildv1.BuildNumberAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return number
+}
@@ -0,0 +1,45 @@
+package builder
+
+import (
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// addConfigReferences adds the ConfigMaps and Secrets referenced by the collected resources, or a DanglingReference
+// for the required ones that do not exist. The ConfigMaps and Secrets that are not referenced are left out
+func (builder *ModelBuilder) addConfigReferences(namespaceModel *model.NamespaceModel,
+	objectsByKind map[schema.GroupVersionKind][]runtime.Object, errorsByKind map[schema.GroupVersionKind]error) {
+	logger.Infof("=== %s/ConfigMaps and Secrets ===", namespaceModel.Name())
+	configResourcesByName := map[string]map[string]model.Resource{"ConfigMap": {}, "Secret": {}}
+	for _, object := range objectsByKind[source.ConfigMapKind] {
+		configMap := *object.(*corev1.ConfigMap)
+		configResourcesByName["ConfigMap"][configMap.Name] = model.ConfigMap{Delegate: configMap}
+	}
+	for _, object := range objectsByKind[source.SecretKind] {
+		secret := *object.(*corev1.Secret)
+		configResourcesByName["Secret"][secret.Name] = model.NewSecret(secret)
+	}
+	kindsByName := map[string]schema.GroupVersionKind{"ConfigMap": source.ConfigMapKind, "Secret": source.SecretKind}
+
+	for _, resource := range namespaceModel.AllResources() {
+		referrer, ok := resource.(model.ConfigReferrer)
+		if !ok {
+			continue
+		}
+		for _, reference := range referrer.ConfigReferences() {
+			if configResource, ok := configResourcesByName[reference.Kind][reference.Name]; ok {
+				namespaceModel.AddResource(configResource)
+				continue
+			}
+			if _, failed := errorsByKind[kindsByName[reference.Kind]]; failed || reference.Optional {
+				continue
+			}
+			logger.Debugf("Missing %s %s for %s %s", reference.Kind, reference.Name, resource.Kind(), resource.Name())
+			namespaceModel.AddResource(model.NewDanglingReference(reference.Kind, namespaceModel.Name(), reference.Name))
+		}
+	}
+}
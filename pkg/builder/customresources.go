@@ -0,0 +1,77 @@
+package builder
+
+import (
+	"fmt"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// customResourceKind is a validated config.CustomResource
+type customResourceKind struct {
+	kind      schema.GroupVersionKind
+	icon      string
+	relations []model.Relation
+}
+
+// initCustomResources validates the configured custom resources
+func (builder *ModelBuilder) initCustomResources() error {
+	builder.customResourceKinds = make([]customResourceKind, 0, len(builder.exporterConfig.CustomResources))
+	for _, customResource := range builder.exporterConfig.CustomResources {
+		groupVersion, err := schema.ParseGroupVersion(customResource.APIVersion)
+		if err != nil {
+			return fmt.Errorf("invalid apiVersion of custom resource %s: %v", customResource.Kind, err)
+		}
+		if customResource.Kind == "" {
+			return fmt.Errorf("missing kind of custom resource %s", customResource.APIVersion)
+		}
+
+		relations := make([]model.Relation, 0, len(customResource.Relations))
+		for _, configRelation := range customResource.Relations {
+			relation := model.Relation{Name: configRelation.Name, Kind: configRelation.Kind,
+				Field: configRelation.Field, Selector: configRelation.Selector}
+			err = relation.Validate()
+			if err != nil {
+				return fmt.Errorf("invalid custom resource %s: %v", customResource.Kind, err)
+			}
+			relations = append(relations, relation)
+		}
+		builder.customResourceKinds = append(builder.customResourceKinds,
+			customResourceKind{kind: groupVersion.WithKind(customResource.Kind), icon: customResource.Icon, relations: relations})
+	}
+	return nil
+}
+
+func (builder *ModelBuilder) addCustomResources(namespaceModel *model.NamespaceModel, objectsByKind map[schema.GroupVersionKind][]runtime.Object) {
+	for _, customResourceKind := range builder.customResourceKinds {
+		logger.Infof("=== %s/%s ===", namespaceModel.Name(), customResourceKind.kind.Kind)
+		for _, object := range objectsByKind[customResourceKind.kind] {
+			customResource, err := asUnstructured(object, customResourceKind.kind)
+			if err != nil {
+				namespaceModel.AddWarning(fmt.Sprintf("Skipped %s: %v", customResourceKind.kind.GroupKind(), err))
+				break
+			}
+			logger.Debugf("Found %s/%s", customResource.GetKind(), customResource.GetName())
+			resource := model.UnstructuredResource{Delegate: *customResource, IconPath: customResourceKind.icon,
+				Relations: customResourceKind.relations}
+			namespaceModel.AddResource(resource)
+		}
+	}
+}
+
+// asUnstructured converts the typed objects, like the ones read from the manifests for the kinds in the Scheme
+func asUnstructured(object runtime.Object, kind schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	if unstructuredObject, ok := object.(*unstructured.Unstructured); ok {
+		return unstructuredObject, nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+	unstructuredObject := &unstructured.Unstructured{Object: content}
+	unstructuredObject.SetGroupVersionKind(kind)
+	return unstructuredObject, nil
+}
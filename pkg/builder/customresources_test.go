@@ -0,0 +1,50 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/dmartinol/openshift-topology-exporter/pkg/config"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	routev1T "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCustomResources(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: objectMeta("api", "", nil),
+		Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "api"}}}
+	route := &routev1T.Route{ObjectMeta: objectMeta("api", "", nil),
+		Spec: routev1T.RouteSpec{To: routev1T.RouteTargetReference{Kind: "Service", Name: "api"}}}
+	// A custom kind sharing the name of a built-in one
+	customService := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1", "kind": "Service",
+		"metadata": map[string]interface{}{"name": "api", "namespace": testNamespace},
+		"spec":     map[string]interface{}{"backend": "api"},
+	}}
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1", "kind": "Widget",
+		"metadata": map[string]interface{}{"name": "w", "namespace": testNamespace},
+		"spec":     map[string]interface{}{"serviceName": "api", "selector": map[string]interface{}{"app": "api"}},
+	}}
+	customResources := []config.CustomResource{
+		{APIVersion: "example.com/v1", Kind: "Service", Relations: []config.Relation{
+			{Name: "backend", Kind: "Service", Field: ".spec.backend"}}},
+		{APIVersion: "example.com/v1", Kind: "Widget", Relations: []config.Relation{
+			{Name: "custom", Kind: "example.com/Service", Field: ".spec.serviceName"},
+			{Name: "selects", Kind: "Pod", Selector: ".spec.selector"}}},
+	}
+
+	namespaceModel := buildTestNamespace(t, config.ExporterConfig{CustomResources: customResources},
+		service, route, customService, widget, runningPod("api-1", map[string]string{"app": "api"}, true))
+	for _, resource := range namespaceModel.ResourcesByKind(model.Service{}.Kind()) {
+		if _, ok := resource.(model.Service); !ok {
+			t.Errorf("found %T in the bucket of the Services", resource)
+		}
+	}
+	assertConnections(t, namespaceModel, []string{
+		"route api -> svc api (exposed)",
+		"example.com/service api -> svc api (backend)",
+		"example.com/widget w -> example.com/service api (custom)",
+		"example.com/widget w -> pod api-1 (selects)",
+	}, []string{"route api -> example.com/service api (exposed)"})
+}
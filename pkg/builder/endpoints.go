@@ -0,0 +1,53 @@
+package builder
+
+import (
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// backendsByService returns the Pods backing each Service, from the EndpointSlices or, when not available, from the
+// Endpoints. The result is nil when none of them were collected, like for manifests exported without endpoints
+func backendsByService(objectsByKind map[schema.GroupVersionKind][]runtime.Object,
+	errorsByKind map[schema.GroupVersionKind]error) map[string][]model.Backend {
+	if _, failed := errorsByKind[source.EndpointSliceKind]; !failed && len(objectsByKind[source.EndpointSliceKind]) > 0 {
+		backends := make(map[string][]model.Backend)
+		for _, object := range objectsByKind[source.EndpointSliceKind] {
+			endpointSlice := object.(*discoveryv1.EndpointSlice)
+			serviceName := endpointSlice.Labels[discoveryv1.LabelServiceName]
+			for _, endpoint := range endpointSlice.Endpoints {
+				if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+					continue
+				}
+				// An unknown readiness is interpreted as ready
+				ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+				backends[serviceName] = append(backends[serviceName], model.Backend{PodName: endpoint.TargetRef.Name, Ready: ready})
+			}
+		}
+		return backends
+	}
+
+	if _, failed := errorsByKind[source.EndpointsKind]; !failed && len(objectsByKind[source.EndpointsKind]) > 0 {
+		backends := make(map[string][]model.Backend)
+		for _, object := range objectsByKind[source.EndpointsKind] {
+			endpoints := object.(*corev1.Endpoints)
+			for _, subset := range endpoints.Subsets {
+				for _, address := range subset.Addresses {
+					if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+						backends[endpoints.Name] = append(backends[endpoints.Name], model.Backend{PodName: address.TargetRef.Name, Ready: true})
+					}
+				}
+				for _, address := range subset.NotReadyAddresses {
+					if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+						backends[endpoints.Name] = append(backends[endpoints.Name], model.Backend{PodName: address.TargetRef.Name, Ready: false})
+					}
+				}
+			}
+		}
+		return backends
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/dmartinol/openshift-topology-exporter/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestServiceSelectors(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: objectMeta("api", "", nil),
+		Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "api"}}}
+	pods := []runtime.Object{
+		runningPod("api-1", map[string]string{"app": "api", "tier": "backend"}, true),
+		runningPod("api-2", map[string]string{"app": "api"}, false),
+		runningPod("web-1", map[string]string{"app": "web"}, true),
+	}
+	endpoints := &corev1.Endpoints{ObjectMeta: objectMeta("api", "", nil), Subsets: []corev1.EndpointSubset{{
+		Addresses:         []corev1.EndpointAddress{{TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "api-1"}}},
+		NotReadyAddresses: []corev1.EndpointAddress{{TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "api-2"}}},
+	}}}
+	noReadyEndpoints := &corev1.Endpoints{ObjectMeta: objectMeta("api", "", nil), Subsets: []corev1.EndpointSubset{{
+		NotReadyAddresses: []corev1.EndpointAddress{{TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "api-2"}}},
+	}}}
+	tests := []struct {
+		name      string
+		objects   []runtime.Object
+		want      []string
+		unwanted  []string
+		wantLabel string
+	}{
+		{"selector", append([]runtime.Object{service}, pods...),
+			[]string{"svc api -> pod api-1", "svc api -> pod api-2"}, []string{"svc api -> pod web-1"}, "api"},
+		{"endpoints", append([]runtime.Object{service, endpoints}, pods...),
+			[]string{"svc api -> pod api-1", "svc api -> pod api-2 (not ready)"}, []string{"svc api -> pod web-1"}, "api"},
+		{"no ready endpoints", append([]runtime.Object{service, noReadyEndpoints}, pods...),
+			[]string{"svc api -> pod api-2 (not ready)"}, []string{"svc api -> pod api-1"}, "api (no ready endpoints)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			namespaceModel := buildTestNamespace(t, config.ExporterConfig{}, test.objects...)
+			assertConnections(t, namespaceModel, test.want, test.unwanted)
+			if label := labelOf(namespaceModel, "Service", "svc api"); label != test.wantLabel {
+				t.Errorf("Service label = %q, want %q", label, test.wantLabel)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package builder
+
+import (
+	"time"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// addEvents attaches the Events of the namespace to the resources they involve, matching the UID of their involved
+// object. The Events of the resources that were not collected are skipped
+func (builder *ModelBuilder) addEvents(namespaceModel *model.NamespaceModel, objectsByKind map[schema.GroupVersionKind][]runtime.Object) {
+	logger.Infof("=== %s/Events ===", namespaceModel.Name())
+	resourcesByUID := make(map[types.UID]model.Resource)
+	for _, resource := range namespaceModel.AllResources() {
+		if uid := model.UIDOf(resource); uid != "" {
+			resourcesByUID[uid] = resource
+		}
+	}
+
+	config := builder.exporterConfig.Events
+	since := config.SinceDuration()
+	for _, object := range objectsByKind[source.EventKind] {
+		event := *object.(*corev1.Event)
+		if config.WarningsOnly && event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		modelEvent := model.NewEvent(event)
+		if since > 0 && time.Since(modelEvent.LastSeen) > since {
+			continue
+		}
+		resource, ok := resourcesByUID[event.InvolvedObject.UID]
+		if !ok {
+			logger.Debugf("Skipped event %s of %s %s", event.Name, event.InvolvedObject.Kind, event.InvolvedObject.Name)
+			continue
+		}
+		namespaceModel.AddEvent(resource, modelEvent)
+	}
+}
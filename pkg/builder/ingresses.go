@@ -0,0 +1,99 @@
+package builder
+
+import (
+	"fmt"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// initIngressClasses lists the cluster-scoped IngressClasses and GatewayClasses, that are added to the topology
+// only when used by the Ingresses and Gateways of the collected namespaces
+func (builder *ModelBuilder) initIngressClasses() error {
+	builder.ingressClasses = make(map[string]model.IngressClass)
+	builder.gatewayClasses = make(map[string]model.GatewayClass)
+
+	ingressClasses, err := builder.listClusterScoped(source.IngressClassKind)
+	if err != nil {
+		return err
+	}
+	for _, object := range ingressClasses {
+		ingressClass := *object.(*networkingv1.IngressClass)
+		builder.ingressClasses[ingressClass.Name] = model.IngressClass{Delegate: ingressClass}
+	}
+	gatewayClasses, err := builder.listClusterScoped(source.GatewayClassKind)
+	if err != nil {
+		return err
+	}
+	for _, object := range gatewayClasses {
+		gatewayClass, err := asUnstructured(object, source.GatewayClassKind)
+		if err != nil {
+			return err
+		}
+		builder.gatewayClasses[gatewayClass.GetName()] = model.GatewayClass{Delegate: *gatewayClass}
+	}
+	logger.Debugf("Found %d IngressClasses and %d GatewayClasses", len(builder.ingressClasses), len(builder.gatewayClasses))
+	return nil
+}
+
+// ingressClassOf returns the IngressClass of the given Ingress, or the default one when not specified
+func (builder *ModelBuilder) ingressClassOf(ingress model.Ingress) (model.IngressClass, bool) {
+	if className := ingress.ClassName(); className != "" {
+		ingressClass, ok := builder.ingressClasses[className]
+		return ingressClass, ok
+	}
+	for _, ingressClass := range builder.ingressClasses {
+		if ingressClass.IsDefault() {
+			return ingressClass, true
+		}
+	}
+	return model.IngressClass{}, false
+}
+
+// addIngresses adds the Ingresses, Gateways and HTTPRoutes of the namespace, connected to their cluster-scoped classes
+func (builder *ModelBuilder) addIngresses(namespaceModel *model.NamespaceModel, objectsByKind map[schema.GroupVersionKind][]runtime.Object) {
+	clusterScoped := builder.topologyModel.ClusterScoped()
+
+	logger.Infof("=== %s/Ingresses ===", namespaceModel.Name())
+	for _, object := range objectsByKind[source.IngressKind] {
+		ingress := *object.(*networkingv1.Ingress)
+		logger.Debugf("Found %s/%s", ingress.Kind, ingress.Name)
+		resource := model.Ingress{Delegate: ingress}
+		namespaceModel.AddResource(resource)
+		if ingressClass, ok := builder.ingressClassOf(resource); ok {
+			clusterScoped.AddResource(ingressClass)
+			clusterScoped.AddConnection(resource, ingressClass)
+		}
+	}
+
+	logger.Infof("=== %s/Gateways ===", namespaceModel.Name())
+	for _, object := range objectsByKind[source.GatewayKind] {
+		gateway, err := asUnstructured(object, source.GatewayKind)
+		if err != nil {
+			namespaceModel.AddWarning(fmt.Sprintf("Skipped %s: %v", source.GatewayKind.GroupKind(), err))
+			break
+		}
+		logger.Debugf("Found %s/%s", gateway.GetKind(), gateway.GetName())
+		resource := model.Gateway{Delegate: *gateway}
+		namespaceModel.AddResource(resource)
+		if gatewayClass, ok := builder.gatewayClasses[resource.ClassName()]; ok {
+			clusterScoped.AddResource(gatewayClass)
+			clusterScoped.AddConnection(resource, gatewayClass)
+		}
+	}
+
+	logger.Infof("=== %s/HTTPRoutes ===", namespaceModel.Name())
+	for _, object := range objectsByKind[source.HTTPRouteKind] {
+		httpRoute, err := asUnstructured(object, source.HTTPRouteKind)
+		if err != nil {
+			namespaceModel.AddWarning(fmt.Sprintf("Skipped %s: %v", source.HTTPRouteKind.GroupKind(), err))
+			break
+		}
+		logger.Debugf("Found %s/%s", httpRoute.GetKind(), httpRoute.GetName())
+		namespaceModel.AddResource(model.HTTPRoute{Delegate: *httpRoute})
+	}
+}
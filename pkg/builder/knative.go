@@ -0,0 +1,270 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	knative "github.com/dmartinol/openshift-topology-exporter/pkg/model/knative"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/serving/pkg/apis/serving"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+// eventSourceLabel marks the CRDs of the Knative event sources
+const eventSourceLabel = "duck.knative.dev/source"
+
+// addRevisions adds the Configurations and Routes of the Knative Services, and the Revisions that receive traffic
+// from a Route or are the latest of their Configuration. The previous Revisions are skipped
+func (builder *ModelBuilder) addRevisions(namespaceModel *model.NamespaceModel, objectsByKind map[schema.GroupVersionKind][]runtime.Object) {
+	namespace := namespaceModel.Name()
+	logger.Infof("=== %s/Knative.Configurations ===", namespace)
+	configurations := make(map[string]knative.Configuration)
+	for _, object := range objectsByKind[source.ConfigurationKind] {
+		configuration := *object.(*servingv1.Configuration)
+		logger.Debugf("Found %s/%s", configuration.Kind, configuration.Name)
+		resource := knative.Configuration{Delegate: configuration}
+		configurations[configuration.Name] = resource
+		namespaceModel.AddResource(resource)
+	}
+
+	logger.Infof("=== %s/Knative.Routes ===", namespace)
+	routes := make([]knative.Route, 0)
+	for _, object := range objectsByKind[source.KnativeRouteKind] {
+		route := *object.(*servingv1.Route)
+		logger.Debugf("Found %s/%s", route.Kind, route.Name)
+		resource := knative.Route{Delegate: route}
+		routes = append(routes, resource)
+		namespaceModel.AddResource(resource)
+	}
+
+	logger.Infof("=== %s/Knative.Revisions ===", namespace)
+	for _, object := range objectsByKind[source.RevisionKind] {
+		revision := *object.(*servingv1.Revision)
+		logger.Debugf("Found %s/%s", revision.Kind, revision.Name)
+		resource := knative.Revision{Delegate: revision}
+		if !isActiveRevision(revision, configurations, routes) {
+			logger.Debugf("Skipping inactive %s/%s", revision.Kind, revision.Name)
+			namespaceModel.SkipResource(resource)
+			continue
+		}
+		namespaceModel.AddResource(resource)
+	}
+}
+
+// isActiveRevision returns true if the Revision receives traffic, is the latest of its Configuration, or its
+// Configuration was not collected
+func isActiveRevision(revision servingv1.Revision, configurations map[string]knative.Configuration, routes []knative.Route) bool {
+	for _, route := range routes {
+		if route.IsRouted(revision.Name) {
+			return true
+		}
+	}
+	configuration, ok := configurations[revision.Labels[serving.ConfigurationLabelKey]]
+	return !ok || configuration.IsLatest(revision.Name)
+}
+
+// addChannels adds the Channels with their Subscriptions, and the Sequences and Parallels. The Channels and
+// Subscriptions created by the Sequences and Parallels are skipped, as their steps are chained by addDestinations,
+// and so are the InMemoryChannels and KafkaChannels backing the skipped Channels, by removeOwnedBySkipped
+func (builder *ModelBuilder) addChannels(namespaceModel *model.NamespaceModel, objectsByKind map[schema.GroupVersionKind][]runtime.Object) {
+	namespace := namespaceModel.Name()
+	logger.Infof("=== %s/Knative.Channels ===", namespace)
+	for _, object := range objectsByKind[source.ChannelKind] {
+		channel := *object.(*messagingv1.Channel)
+		logger.Debugf("Found %s/%s", channel.Kind, channel.Name)
+		addOrSkip(namespaceModel, knative.Channel{Delegate: channel}, channel.OwnerReferences)
+	}
+	for _, object := range objectsByKind[source.InMemoryChannelKind] {
+		channel := *object.(*messagingv1.InMemoryChannel)
+		logger.Debugf("Found %s/%s", channel.Kind, channel.Name)
+		addOrSkip(namespaceModel, knative.InMemoryChannel{Delegate: channel}, channel.OwnerReferences)
+	}
+	for _, object := range objectsByKind[source.KafkaChannelKind] {
+		channel, err := asUnstructured(object, source.KafkaChannelKind)
+		if err != nil {
+			namespaceModel.AddWarning(fmt.Sprintf("Skipped %s: %v", source.KafkaChannelKind.GroupKind(), err))
+			break
+		}
+		logger.Debugf("Found %s/%s", channel.GetKind(), channel.GetName())
+		addOrSkip(namespaceModel, knative.KafkaChannel{Delegate: *channel}, channel.GetOwnerReferences())
+	}
+
+	logger.Infof("=== %s/Knative.Subscriptions ===", namespace)
+	for _, object := range objectsByKind[source.SubscriptionKind] {
+		subscription := *object.(*messagingv1.Subscription)
+		logger.Debugf("Found %s/%s", subscription.Kind, subscription.Name)
+		addOrSkip(namespaceModel, knative.Subscription{Delegate: subscription}, subscription.OwnerReferences)
+	}
+
+	logger.Infof("=== %s/Knative.Sequences ===", namespace)
+	for _, object := range objectsByKind[source.SequenceKind] {
+		sequence := *object.(*flowsv1.Sequence)
+		logger.Debugf("Found %s/%s", sequence.Kind, sequence.Name)
+		namespaceModel.AddResource(knative.Sequence{Delegate: sequence})
+	}
+
+	logger.Infof("=== %s/Knative.Parallels ===", namespace)
+	for _, object := range objectsByKind[source.ParallelKind] {
+		parallel := *object.(*flowsv1.Parallel)
+		logger.Debugf("Found %s/%s", parallel.Kind, parallel.Name)
+		namespaceModel.AddResource(knative.Parallel{Delegate: parallel})
+	}
+}
+
+// addDestinations connects the resources sending events to their Destinations, and the steps of the flows in order.
+// The Destinations that are URIs, or references to another namespace or to a resource that was not collected, are
+// added as Endpoints
+func (builder *ModelBuilder) addDestinations(namespaceModel *model.NamespaceModel) {
+	for _, resource := range namespaceModel.AllResources() {
+		if addresser, ok := resource.(knative.Addresser); ok {
+			for _, destination := range addresser.Destinations() {
+				to := resolveDestination(namespaceModel, destination.Destination)
+				if to == nil {
+					continue
+				}
+				namespaceModel.AddNamedConnection(resource, to, destination.Name)
+			}
+		}
+		if flow, ok := resource.(knative.Flow); ok {
+			for _, hop := range flow.Hops() {
+				from := resource
+				if hop.From != nil {
+					from = resolveDestination(namespaceModel, hop.From)
+				}
+				to := resolveDestination(namespaceModel, hop.To)
+				if from == nil || to == nil {
+					continue
+				}
+				namespaceModel.AddNamedConnection(from, to, hop.Name)
+			}
+		}
+	}
+}
+
+// resolveDestination returns the resource of the namespace referenced by the given Destination, or an Endpoint added
+// to the namespace when not found. It returns nil for an empty Destination
+func resolveDestination(namespaceModel *model.NamespaceModel, destination *duckv1.Destination) model.Resource {
+	ref := destination.Ref
+	if ref == nil && destination.URI == nil {
+		return nil
+	}
+	if ref != nil && (ref.Namespace == "" || ref.Namespace == namespaceModel.Name()) {
+		for _, resource := range namespaceModel.ResourcesByKind(knative.KindOf(ref)) {
+			if knative.RefersTo(ref, resource) {
+				return resource
+			}
+		}
+		logger.Debugf("Destination %s of kind %s not found", ref.Name, ref.Kind)
+	}
+	endpoint := knative.Endpoint{Delegate: *destination}
+	namespaceModel.AddResource(endpoint)
+	return namespaceModel.LookupByKindAndId(endpoint.Kind(), endpoint.Id())
+}
+
+// addOrSkip adds the given Channel or Subscription, unless it was created by a Sequence or a Parallel
+func addOrSkip(namespaceModel *model.NamespaceModel, resource model.Resource, owners []metav1.OwnerReference) {
+	if isOwnedByFlow(owners) {
+		namespaceModel.SkipResource(resource)
+		return
+	}
+	namespaceModel.AddResource(resource)
+}
+
+// isOwnedByFlow returns true if one of the owners is a Sequence or a Parallel
+func isOwnedByFlow(owners []metav1.OwnerReference) bool {
+	for _, owner := range owners {
+		groupVersion, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err == nil && strings.Compare(groupVersion.Group, flowsv1.SchemeGroupVersion.Group) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// typedEventSourceKinds are the event sources with a typed model, skipped when discovering the other ones
+var typedEventSourceKinds = []schema.GroupVersionKind{source.PingSourceKind, source.ApiServerSourceKind,
+	source.ContainerSourceKind, source.SinkBindingKind}
+
+// initEventSources discovers the kinds of the event sources from the CRDs labelled with eventSourceLabel
+func (builder *ModelBuilder) initEventSources() error {
+	builder.eventSourceKinds = make([]schema.GroupVersionKind, 0)
+	crds, err := builder.listClusterScoped(source.CustomResourceDefinitionKind)
+	if err != nil {
+		return err
+	}
+	for _, object := range crds {
+		crd, err := asUnstructured(object, source.CustomResourceDefinitionKind)
+		if err != nil {
+			return err
+		}
+		if crd.GetLabels()[eventSourceLabel] != "true" {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+		eventSourceKind := schema.GroupVersionKind{Group: group, Kind: kind}
+		if kind == "" || isTypedEventSource(eventSourceKind) {
+			continue
+		}
+		logger.Debugf("Found event source kind %s", eventSourceKind.GroupKind())
+		builder.eventSourceKinds = append(builder.eventSourceKinds, eventSourceKind)
+	}
+	return nil
+}
+
+func isTypedEventSource(kind schema.GroupVersionKind) bool {
+	for _, typedKind := range typedEventSourceKinds {
+		if typedKind.GroupKind() == kind.GroupKind() {
+			return true
+		}
+	}
+	return false
+}
+
+// addEventSources adds the PingSources, ApiServerSources and ContainerSources, and the sources of the discovered kinds
+func (builder *ModelBuilder) addEventSources(namespaceModel *model.NamespaceModel, objectsByKind map[schema.GroupVersionKind][]runtime.Object) {
+	namespace := namespaceModel.Name()
+	logger.Infof("=== %s/Knative.PingSources ===", namespace)
+	for _, object := range objectsByKind[source.PingSourceKind] {
+		pingSource := *object.(*sourcesv1.PingSource)
+		logger.Debugf("Found %s/%s", pingSource.Kind, pingSource.Name)
+		namespaceModel.AddResource(knative.PingSource{Delegate: pingSource})
+	}
+
+	logger.Infof("=== %s/Knative.ApiServerSources ===", namespace)
+	for _, object := range objectsByKind[source.ApiServerSourceKind] {
+		apiServerSource := *object.(*sourcesv1.ApiServerSource)
+		logger.Debugf("Found %s/%s", apiServerSource.Kind, apiServerSource.Name)
+		namespaceModel.AddResource(knative.ApiServerSource{Delegate: apiServerSource})
+	}
+
+	logger.Infof("=== %s/Knative.ContainerSources ===", namespace)
+	for _, object := range objectsByKind[source.ContainerSourceKind] {
+		containerSource := *object.(*sourcesv1.ContainerSource)
+		logger.Debugf("Found %s/%s", containerSource.Kind, containerSource.Name)
+		namespaceModel.AddResource(knative.ContainerSource{Delegate: containerSource})
+	}
+
+	for _, kind := range builder.eventSourceKinds {
+		logger.Infof("=== %s/Knative.%s ===", namespace, kind.Kind)
+		for _, object := range objectsByKind[kind] {
+			eventSource, err := asUnstructured(object, kind)
+			if err != nil {
+				namespaceModel.AddWarning(fmt.Sprintf("Skipped %s: %v", kind.GroupKind(), err))
+				break
+			}
+			logger.Debugf("Found %s/%s", eventSource.GetKind(), eventSource.GetName())
+			namespaceModel.AddResource(knative.EventSource{Delegate: *eventSource})
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/dmartinol/openshift-topology-exporter/pkg/config"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/serving/pkg/apis/serving"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+// assertResources checks which of the given resources, by kind and Id, are in the namespace
+func assertResources(t *testing.T, namespaceModel *model.NamespaceModel, present map[[2]string]bool) {
+	t.Helper()
+	for resource, want := range present {
+		if got := namespaceModel.LookupByKindAndId(resource[0], resource[1]) != nil; got != want {
+			t.Errorf("%s of kind %s present = %v, want %v", resource[1], resource[0], got, want)
+		}
+	}
+	for _, resource := range namespaceModel.AllResources() {
+		if _, ok := resource.(model.UnstructuredResource); ok {
+			t.Errorf("skipped owner %s of kind %s fetched back", resource.Name(), resource.Kind())
+		}
+	}
+}
+
+func TestSkippedRevisions(t *testing.T) {
+	knativeService := &servingv1.Service{ObjectMeta: objectMeta("hello", "ks1", nil)}
+	configuration := &servingv1.Configuration{ObjectMeta: objectMeta("hello", "kc1", nil,
+		controllerRef("serving.knative.dev/v1", "Service", "hello", "ks1"))}
+	configuration.Status.LatestCreatedRevisionName = "hello-2"
+	percent := int64(100)
+	route := &servingv1.Route{ObjectMeta: objectMeta("hello", "kr1", nil,
+		controllerRef("serving.knative.dev/v1", "Service", "hello", "ks1"))}
+	route.Status.Traffic = []servingv1.TrafficTarget{{RevisionName: "hello-2", Percent: &percent, Tag: "latest"}}
+	revisionLabels := map[string]string{serving.ConfigurationLabelKey: "hello"}
+	configurationRef := controllerRef("serving.knative.dev/v1", "Configuration", "hello", "kc1")
+	oldRevision := &servingv1.Revision{ObjectMeta: objectMeta("hello-1", "rev1", revisionLabels, configurationRef)}
+	latestRevision := &servingv1.Revision{ObjectMeta: objectMeta("hello-2", "rev2", revisionLabels, configurationRef)}
+	oldDeployment := &appsv1.Deployment{ObjectMeta: objectMeta("hello-1-deployment", "d1", nil,
+		controllerRef("serving.knative.dev/v1", "Revision", "hello-1", "rev1"))}
+	oldReplicaSet := &appsv1.ReplicaSet{ObjectMeta: objectMeta("hello-1-deployment-x", "r1", nil,
+		controllerRef("apps/v1", "Deployment", "hello-1-deployment", "d1"))}
+	latestDeployment := &appsv1.Deployment{ObjectMeta: objectMeta("hello-2-deployment", "d2", nil,
+		controllerRef("serving.knative.dev/v1", "Revision", "hello-2", "rev2"))}
+
+	namespaceModel := buildTestNamespace(t, config.ExporterConfig{KNative: true}, knativeService, configuration, route,
+		oldRevision, latestRevision, oldDeployment, oldReplicaSet, latestDeployment)
+	assertResources(t, namespaceModel, map[[2]string]bool{
+		{"knative.Revision", "revision hello-2"}:        true,
+		{"Deployment", "deployment hello-2-deployment"}: true,
+		{"knative.Revision", "revision hello-1"}:        false,
+		{"Deployment", "deployment hello-1-deployment"}: false,
+		{"ReplicaSet", "rs hello-1-deployment-x"}:       false,
+	})
+	assertConnections(t, namespaceModel, []string{"kroute hello -> revision hello-2 (100%, tag latest)"}, nil)
+}
+
+func TestSkippedFlowChannels(t *testing.T) {
+	sequenceRef := controllerRef("flows.knative.dev/v1", "Sequence", "seq", "sq1")
+	flowChannel := &messagingv1.Channel{ObjectMeta: objectMeta("seq-kn-sequence-0", "ch1", nil, sequenceRef)}
+	flowInMemoryChannel := &messagingv1.InMemoryChannel{ObjectMeta: objectMeta("seq-kn-sequence-0", "imc1", nil,
+		controllerRef("messaging.knative.dev/v1", "Channel", "seq-kn-sequence-0", "ch1"))}
+	channel := &messagingv1.Channel{ObjectMeta: objectMeta("orders", "ch2", nil)}
+	inMemoryChannel := &messagingv1.InMemoryChannel{ObjectMeta: objectMeta("orders", "imc2", nil,
+		controllerRef("messaging.knative.dev/v1", "Channel", "orders", "ch2"))}
+
+	namespaceModel := buildTestNamespace(t, config.ExporterConfig{KNative: true},
+		flowChannel, flowInMemoryChannel, channel, inMemoryChannel)
+	assertResources(t, namespaceModel, map[[2]string]bool{
+		{"knative.Channel", "channel orders"}:                true,
+		{"knative.InMemoryChannel", "imc orders"}:            true,
+		{"knative.Channel", "channel seq-kn-sequence-0"}:     false,
+		{"knative.InMemoryChannel", "imc seq-kn-sequence-0"}: false,
+	})
+}
+
+func TestFlows(t *testing.T) {
+	ksvcRef := func(name string) duckv1.Destination {
+		return duckv1.Destination{Ref: &duckv1.KReference{APIVersion: "serving.knative.dev/v1", Kind: "Service", Name: name}}
+	}
+	services := make([]runtime.Object, 0)
+	for _, name := range []string{"first", "second", "filter", "display"} {
+		services = append(services, &servingv1.Service{ObjectMeta: objectMeta(name, "", nil)})
+	}
+	display := ksvcRef("display")
+	filter := ksvcRef("filter")
+	sequence := &flowsv1.Sequence{ObjectMeta: objectMeta("seq", "", nil), Spec: flowsv1.SequenceSpec{
+		Steps: []flowsv1.SequenceStep{{Destination: ksvcRef("first")}, {Destination: ksvcRef("second")}},
+		Reply: &display}}
+	parallel := &flowsv1.Parallel{ObjectMeta: objectMeta("par", "", nil), Spec: flowsv1.ParallelSpec{
+		Branches: []flowsv1.ParallelBranch{{Filter: &filter, Subscriber: ksvcRef("first")}, {Subscriber: ksvcRef("second")}},
+		Reply:    &display}}
+
+	namespaceModel := buildTestNamespace(t, config.ExporterConfig{KNative: true}, append(services, sequence, parallel)...)
+	assertConnections(t, namespaceModel, []string{
+		"sequence seq -> ksvc first (seq step 1)",
+		"ksvc first -> ksvc second (seq step 2)",
+		"ksvc second -> ksvc display (seq reply)",
+		"parallel par -> ksvc filter (par branch 1 filter)",
+		"ksvc filter -> ksvc first (par branch 1)",
+		"ksvc first -> ksvc display (par branch 1 reply)",
+		"parallel par -> ksvc second (par branch 2)",
+	}, nil)
+}
+
+func TestDestinations(t *testing.T) {
+	display := &servingv1.Service{ObjectMeta: objectMeta("display", "", nil)}
+	broker := &eventingv1.Broker{ObjectMeta: objectMeta("default", "", nil)}
+	dlsURI, _ := apis.ParseURL("http://dls.example.com")
+	ksvcRef := func(name string, namespace string) duckv1.Destination {
+		return duckv1.Destination{Ref: &duckv1.KReference{APIVersion: "serving.knative.dev/v1", Kind: "Service",
+			Name: name, Namespace: namespace}}
+	}
+	trigger := func(subscriber duckv1.Destination, filter map[string]string) *eventingv1.Trigger {
+		trigger := &eventingv1.Trigger{ObjectMeta: objectMeta("orders", "", nil)}
+		trigger.Spec.Broker = "default"
+		trigger.Spec.Subscriber = subscriber
+		if filter != nil {
+			trigger.Spec.Filter = &eventingv1.TriggerFilter{Attributes: filter}
+		}
+		return trigger
+	}
+	withDeadLetterSink := trigger(ksvcRef("display", ""), nil)
+	withDeadLetterSink.Spec.Delivery = &eventingduckv1.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: dlsURI}}
+
+	tests := []struct {
+		name    string
+		trigger *eventingv1.Trigger
+		want    []string
+	}{
+		{"same namespace", trigger(ksvcRef("display", ""), nil),
+			[]string{"trigger orders -> broker default (broker)", "trigger orders -> ksvc display (subscriber)"}},
+		{"explicit namespace", trigger(ksvcRef("display", testNamespace), nil),
+			[]string{"trigger orders -> ksvc display (subscriber)"}},
+		{"filtered", trigger(ksvcRef("display", ""), map[string]string{"type": "created", "source": "shop"}),
+			[]string{"trigger orders -> ksvc display (source=shop, type=created)"}},
+		{"other namespace", trigger(ksvcRef("display", "ns2"), nil),
+			[]string{"trigger orders -> endpoint Service ns2/display (subscriber)"}},
+		{"not collected", trigger(ksvcRef("missing", ""), nil),
+			[]string{"trigger orders -> endpoint Service missing (subscriber)"}},
+		{"URI", trigger(duckv1.Destination{URI: dlsURI}, nil),
+			[]string{"trigger orders -> endpoint http://dls.example.com (subscriber)"}},
+		{"dead letter sink", withDeadLetterSink,
+			[]string{"trigger orders -> ksvc display (subscriber)", "trigger orders -> endpoint http://dls.example.com (dead letter)"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			namespaceModel := buildTestNamespace(t, config.ExporterConfig{KNative: true}, display, broker, test.trigger)
+			assertConnections(t, namespaceModel, test.want, nil)
+		})
+	}
+}
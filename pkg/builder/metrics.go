@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"fmt"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// podUsages returns the CPU and memory usage of the Pods, summed over their containers, from their PodMetrics
+func podUsages(namespaceModel *model.NamespaceModel, objectsByKind map[schema.GroupVersionKind][]runtime.Object) map[string]corev1.ResourceList {
+	usages := make(map[string]corev1.ResourceList)
+	for _, object := range objectsByKind[source.PodMetricsKind] {
+		podMetrics, err := asUnstructured(object, source.PodMetricsKind)
+		if err != nil {
+			namespaceModel.AddWarning(fmt.Sprintf("Skipped %s: %v", source.PodMetricsKind.GroupKind(), err))
+			continue
+		}
+		containers, _, _ := unstructured.NestedSlice(podMetrics.Object, "containers")
+		usage := corev1.ResourceList{corev1.ResourceCPU: resource.Quantity{}, corev1.ResourceMemory: resource.Quantity{}}
+		for _, value := range containers {
+			container, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			containerUsage, _, _ := unstructured.NestedStringMap(container, "usage")
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				quantity, err := resource.ParseQuantity(containerUsage[string(name)])
+				if err != nil {
+					logger.Debugf("Skipped %s usage of %s: %v", name, podMetrics.GetName(), err)
+					continue
+				}
+				sum := usage[name]
+				sum.Add(quantity)
+				usage[name] = sum
+			}
+		}
+		usages[podMetrics.GetName()] = usage
+	}
+	return usages
+}
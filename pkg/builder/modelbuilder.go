@@ -2,85 +2,107 @@ package builder
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/dmartinol/openshift-topology-exporter/pkg/config"
 	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
 	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
 	knative "github.com/dmartinol/openshift-topology-exporter/pkg/model/knative"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	appsv1T "github.com/openshift/api/apps/v1"
 	authv1T "github.com/openshift/api/authorization/v1"
-	appsv1 "github.com/openshift/client-go/apps/clientset/versioned/typed/apps/v1"
-	authv1 "github.com/openshift/client-go/authorization/clientset/versioned/typed/authorization/v1"
-	routev1 "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	k8appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
-	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
-	eventingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/eventing/v1"
-	sourcesv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/sources/v1"
-	servingv1 "knative.dev/serving/pkg/client/clientset/versioned/typed/serving/v1"
+	routev1T "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	"k8s.io/client-go/rest"
 )
 
 type ModelBuilder struct {
 	exporterConfig config.ExporterConfig
-	routeClient    *routev1.RouteV1Client
-	appsClient     *appsv1.AppsV1Client
-	appsV1Client   *k8appsv1client.AppsV1Client
-	coreClient     *corev1client.CoreV1Client
-	authClient     *authv1.AuthorizationV1Client
-	eventingClient *eventingv1.EventingV1Client
-	servingClient  *servingv1.ServingV1Client
-	sourcesClient  *sourcesv1.SourcesV1Client
+	source         source.Source
 
 	topologyModel       *model.TopologyModel
-	namespaceModel      *model.NamespaceModel
 	clusterRoleBindings *authv1T.ClusterRoleBindingList
+	// Bounds the number of concurrent requests to the Source
+	requests            chan struct{}
+	customResourceKinds []customResourceKind
+	persistentVolumes   map[string]corev1.PersistentVolume
+	storageClasses      map[string]storagev1.StorageClass
+	ingressClasses      map[string]model.IngressClass
+	gatewayClasses      map[string]model.GatewayClass
+	// The kinds of the Knative event sources without a typed model, discovered from their CRD
+	eventSourceKinds []schema.GroupVersionKind
 }
 
 func NewModelBuilder(exporterConfig config.ExporterConfig) *ModelBuilder {
 	builder := ModelBuilder{exporterConfig: exporterConfig}
 	builder.topologyModel = model.NewTopologyModel()
+	builder.requests = make(chan struct{}, exporterConfig.ParallelismOrDefault())
 	return &builder
 }
 
 func (builder *ModelBuilder) BuildForConfig(config *rest.Config) (*model.TopologyModel, error) {
-	var err error
-	builder.routeClient, err = routev1.NewForConfig(config)
+	liveSource, err := source.NewLiveSource(config)
 	if err != nil {
 		return nil, err
 	}
-	builder.appsClient, err = appsv1.NewForConfig(config)
+	return builder.Build(liveSource)
+}
+
+// BuildForManifests builds the topology offline, from the manifests exported in the given directory or tarball.
+// When no namespaces are configured or selected, all the namespaces found in the manifests are exported
+func (builder *ModelBuilder) BuildForManifests(path string) (*model.TopologyModel, error) {
+	manifestSource, err := source.NewManifestSource(path)
 	if err != nil {
 		return nil, err
 	}
-	builder.appsV1Client, err = k8appsv1client.NewForConfig(config)
-	if err != nil {
-		return nil, err
+	if len(builder.exporterConfig.Namespaces) == 0 && !builder.exporterConfig.NamespaceSelector.IsEnabled() {
+		builder.exporterConfig.Namespaces = manifestSource.Namespaces()
 	}
-	builder.coreClient, err = corev1client.NewForConfig(config)
+	topologyModel, err := builder.Build(manifestSource)
 	if err != nil {
 		return nil, err
 	}
-	builder.authClient, err = authv1.NewForConfig(config)
-	if err != nil {
-		return nil, err
+	// The manifests of the kinds that are not collected, or not enabled like the Knative ones, are not drawn.
+	// The Namespaces are not reported, as they are derived from the manifests when missing
+	unlisted := manifestSource.Unlisted()
+	kinds := make([]schema.GroupVersionKind, 0, len(unlisted))
+	for kind := range unlisted {
+		if kind.GroupKind() != source.NamespaceKind.GroupKind() {
+			kinds = append(kinds, kind)
+		}
 	}
-
-	builder.eventingClient, err = eventingv1.NewForConfig(config)
-	if err != nil {
-		return nil, err
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].String() < kinds[j].String() })
+	for _, kind := range kinds {
+		logger.Warnf("Ignored %d manifests of kind %s in %s that is not collected", unlisted[kind], kind.Kind, kind.GroupVersion())
 	}
+	return topologyModel, nil
+}
 
-	builder.servingClient, err = servingv1.NewForConfig(config)
+// Build builds the topology of the configured namespaces from the resources of the given Source
+func (builder *ModelBuilder) Build(source source.Source) (*model.TopologyModel, error) {
+	builder.source = source
+	err := builder.initCustomResources()
 	if err != nil {
 		return nil, err
 	}
-
-	builder.sourcesClient, err = sourcesv1.NewForConfig(config)
+	err = builder.discoverNamespaces()
 	if err != nil {
 		return nil, err
 	}
-
 	err = builder.buildCluster()
 	if err != nil {
 		return nil, err
@@ -90,29 +112,191 @@ func (builder *ModelBuilder) BuildForConfig(config *rest.Config) (*model.Topolog
 }
 
 func (builder *ModelBuilder) buildCluster() error {
-	var err error
-	builder.clusterRoleBindings, err = builder.authClient.ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	builder.clusterRoleBindings = &authv1T.ClusterRoleBindingList{}
+	clusterRoleBindings, err := builder.source.List(source.ClusterRoleBindingKind, "")
+	if err != nil {
+		if builder.exporterConfig.Strict {
+			return err
+		}
+		builder.topologyModel.AddWarning(fmt.Sprintf("Skipped %s: %v", source.ClusterRoleBindingKind.GroupKind(), err))
+	}
+	err = meta.SetList(builder.clusterRoleBindings, clusterRoleBindings)
 	if err != nil {
 		return err
 	}
 	for _, clusterRoleBinding := range builder.clusterRoleBindings.Items {
 		logger.Debugf("Found ClusterRoleBindings %s/%s", clusterRoleBinding.RoleRef.Name, clusterRoleBinding.UserNames)
 	}
-
-	for _, namespace := range builder.exporterConfig.Namespaces {
-		err := builder.buildNamespace(namespace)
+	err = builder.initStorage()
+	if err != nil {
+		return err
+	}
+	err = builder.initIngressClasses()
+	if err != nil {
+		return err
+	}
+	if builder.exporterConfig.KNative {
+		err = builder.initEventSources()
 		if err != nil {
 			return err
 		}
 	}
+
+	start := time.Now()
+	// In strict mode, the first failure cancels the namespaces that are not built yet
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	namespaceModels := make(chan *model.NamespaceModel)
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var firstErr error
+	for i := 0; i < builder.exporterConfig.ParallelismOrDefault(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for namespaceModel := range namespaceModels {
+				if ctx.Err() != nil {
+					continue
+				}
+				err := builder.buildNamespace(namespaceModel)
+				if err != nil {
+					if !builder.exporterConfig.Strict {
+						namespaceModel.AddWarning(fmt.Sprintf("Incomplete namespace: %v", err))
+						continue
+					}
+					lock.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					lock.Unlock()
+					cancel()
+				}
+			}
+		}()
+	}
+	// Namespace models are added upfront to preserve the configured order
+sendLoop:
+	for _, namespace := range builder.exporterConfig.Namespaces {
+		namespaceModel := builder.topologyModel.AddNamespace(namespace)
+		select {
+		case <-ctx.Done():
+			break sendLoop
+		case namespaceModels <- namespaceModel:
+		}
+	}
+	close(namespaceModels)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	builder.connectResources(builder.topologyModel.ClusterScoped())
+	logger.Infof("Built %d namespaces in %s", len(builder.exporterConfig.Namespaces), time.Since(start))
+	builder.logWarnings()
 	return nil
 }
 
-func (builder *ModelBuilder) buildNamespace(namespace string) error {
-	builder.namespaceModel = builder.topologyModel.AddNamespace(namespace)
+// logWarnings logs the summary of the resources that could not be collected
+func (builder *ModelBuilder) logWarnings() {
+	count := 0
+	for _, warning := range builder.topologyModel.Warnings() {
+		logger.Warnf("Cluster: %s", warning)
+		count++
+	}
+	for _, namespaceModel := range builder.topologyModel.AllNamespaces() {
+		for _, warning := range namespaceModel.Warnings() {
+			logger.Warnf("NS %s: %s", namespaceModel.Name(), warning)
+			count++
+		}
+	}
+	if count > 0 {
+		logger.Warnf("The topology is incomplete: %d warnings found (set strict: true to fail instead)", count)
+	}
+}
+
+// optionalKinds are not served by all the clusters, and are silently skipped when missing
+var optionalKinds = []schema.GroupVersionKind{source.GatewayKind, source.HTTPRouteKind, source.GatewayClassKind,
+	source.EndpointSliceKind, source.PodMetricsKind, source.ImageStreamKind, source.BuildConfigKind, source.BuildKind,
+	source.KafkaChannelKind}
+
+// isNotServed returns true if the error tells that an optional kind is not served by the cluster
+func isNotServed(kind schema.GroupVersionKind, err error) bool {
+	for _, optionalKind := range optionalKinds {
+		if kind == optionalKind && (meta.IsNoMatchError(err) || errors.IsNotFound(err)) {
+			logger.Debugf("Skipped %s: not served by the cluster", kind.GroupKind())
+			return true
+		}
+	}
+	return false
+}
+
+// listKinds lists concurrently all the given kinds in the namespace, returning the errors of the failed kinds
+func (builder *ModelBuilder) listKinds(namespace string, kinds []schema.GroupVersionKind) (map[schema.GroupVersionKind][]runtime.Object, map[schema.GroupVersionKind]error) {
+	objectsByKind := make(map[schema.GroupVersionKind][]runtime.Object)
+	errorsByKind := make(map[schema.GroupVersionKind]error)
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	for _, kind := range kinds {
+		wg.Add(1)
+		go func(kind schema.GroupVersionKind) {
+			defer wg.Done()
+			builder.requests <- struct{}{}
+			objects, err := builder.source.List(kind, namespace)
+			<-builder.requests
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil && !isNotServed(kind, err) {
+				errorsByKind[kind] = err
+				return
+			}
+			objectsByKind[kind] = objects
+		}(kind)
+	}
+	wg.Wait()
+	return objectsByKind, errorsByKind
+}
 
+func (builder *ModelBuilder) buildNamespace(namespaceModel *model.NamespaceModel) error {
+	namespace := namespaceModel.Name()
 	logger.Infof("Running on NS %s", namespace)
-	roleBindings, err := builder.authClient.RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+	start := time.Now()
+	kinds := []schema.GroupVersionKind{source.RoleBindingKind, source.RouteKind, source.ServiceKind, source.DeploymentKind,
+		source.StatefulSetKind, source.DeploymentConfigKind, source.DaemonSetKind, source.JobKind, source.CronJobKind,
+		source.ReplicaSetKind, source.ReplicationControllerKind,
+		source.PodKind, source.ServiceAccountKind, source.ConfigMapKind, source.SecretKind, source.PersistentVolumeClaimKind,
+		source.IngressKind, source.GatewayKind, source.HTTPRouteKind, source.NetworkPolicyKind,
+		source.EndpointsKind, source.EndpointSliceKind, source.ImageStreamKind, source.BuildConfigKind, source.BuildKind}
+	if builder.exporterConfig.KNative {
+		kinds = append(kinds, source.KnativeServiceKind, source.ConfigurationKind, source.RevisionKind, source.KnativeRouteKind,
+			source.SinkBindingKind, source.BrokerKind, source.TriggerKind, source.ChannelKind, source.InMemoryChannelKind,
+			source.KafkaChannelKind, source.SubscriptionKind, source.SequenceKind, source.ParallelKind,
+			source.PingSourceKind, source.ApiServerSourceKind, source.ContainerSourceKind)
+		kinds = append(kinds, builder.eventSourceKinds...)
+	}
+	if builder.exporterConfig.Events.Enabled {
+		kinds = append(kinds, source.EventKind)
+	}
+	if builder.exporterConfig.Metrics.Enabled {
+		kinds = append(kinds, source.PodMetricsKind)
+	}
+	for _, customResourceKind := range builder.customResourceKinds {
+		kinds = append(kinds, customResourceKind.kind)
+	}
+	objectsByKind, errorsByKind := builder.listKinds(namespace, kinds)
+	for _, kind := range kinds {
+		if err, ok := errorsByKind[kind]; ok {
+			if builder.exporterConfig.Strict {
+				return err
+			}
+			namespaceModel.AddWarning(fmt.Sprintf("Skipped %s: %v", kind.GroupKind(), err))
+		}
+	}
+	listDuration := time.Since(start)
+
+	roleBindingObjects := objectsByKind[source.RoleBindingKind]
+	roleBindings := &authv1T.RoleBindingList{}
+	err := meta.SetList(roleBindings, roleBindingObjects)
 	if err != nil {
 		return err
 	}
@@ -120,182 +304,263 @@ func (builder *ModelBuilder) buildNamespace(namespace string) error {
 		logger.Debugf("Found RoleBinding %s/%s", roleBinding.RoleRef.Name, roleBinding.UserNames)
 	}
 
-	logger.Info("=== Routes ===")
-	routes, err := builder.routeClient.Routes(namespace).List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	for _, route := range routes.Items {
+	logger.Infof("=== %s/Routes ===", namespace)
+	routes := objectsByKind[source.RouteKind]
+	for _, object := range routes {
+		route := *object.(*routev1T.Route)
 		logger.Debugf("Found %s/%s", route.Kind, route.Name)
 		resource := model.Route{Delegate: route}
-		builder.namespaceModel.AddResource(resource)
+		namespaceModel.AddResource(resource)
 	}
 
-	logger.Info("=== Services ===")
-	services, err := builder.coreClient.Services(namespace).List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	for _, service := range services.Items {
+	logger.Infof("=== %s/Services ===", namespace)
+	services := objectsByKind[source.ServiceKind]
+	backends := backendsByService(objectsByKind, errorsByKind)
+	for _, object := range services {
+		service := *object.(*corev1.Service)
 		logger.Debugf("Found %s/%s", service.Kind, service.Name)
 		if model.IsKNativeSkippableService(service) {
 			logger.Infof("Skipping Knative service %s/%s", service.Kind, service.Name)
 		} else {
 			resource := model.Service{Delegate: service}
-			builder.namespaceModel.AddResource(resource)
+			if backends != nil {
+				resource.Backends = append([]model.Backend{}, backends[service.Name]...)
+			}
+			namespaceModel.AddResource(resource)
 		}
 	}
 
-	logger.Info("=== Deployments ===")
-	deployments, err := builder.appsV1Client.Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	for _, deployment := range deployments.Items {
+	logger.Infof("=== %s/Deployments ===", namespace)
+	deployments := objectsByKind[source.DeploymentKind]
+	for _, object := range deployments {
+		deployment := *object.(*appsv1.Deployment)
 		logger.Debugf("Found %s/%s", deployment.Kind, deployment.Name)
 		resource := model.Deployment{Delegate: deployment}
-		builder.namespaceModel.AddResource(resource)
+		namespaceModel.AddResource(resource)
 	}
 
-	logger.Info("=== StatefulSets ===")
-	statefulSets, err := builder.appsV1Client.StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	for _, statefulSet := range statefulSets.Items {
+	logger.Infof("=== %s/StatefulSets ===", namespace)
+	statefulSets := objectsByKind[source.StatefulSetKind]
+	for _, object := range statefulSets {
+		statefulSet := *object.(*appsv1.StatefulSet)
 		logger.Debugf("Found %s/%s", statefulSet.Kind, statefulSet.Name)
 		resource := model.StatefulSet{Delegate: statefulSet}
-		builder.namespaceModel.AddResource(resource)
+		namespaceModel.AddResource(resource)
 	}
 
-	logger.Info("=== DeploymentConfigs ===")
-	deploymentConfigs, err := builder.appsClient.DeploymentConfigs(namespace).List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	for _, deploymentConfig := range deploymentConfigs.Items {
+	logger.Infof("=== %s/DeploymentConfigs ===", namespace)
+	deploymentConfigs := objectsByKind[source.DeploymentConfigKind]
+	for _, object := range deploymentConfigs {
+		deploymentConfig := *object.(*appsv1T.DeploymentConfig)
 		logger.Debugf("Found %s/%s", deploymentConfig.Kind, deploymentConfig.Name)
 		resource := model.DeploymentConfig{Delegate: deploymentConfig}
-		builder.namespaceModel.AddResource(resource)
+		namespaceModel.AddResource(resource)
 	}
 
-	logger.Info("=== Pods ===")
-	pods, err := builder.coreClient.Pods(namespace).List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		return err
+	logger.Infof("=== %s/DaemonSets ===", namespace)
+	daemonSets := objectsByKind[source.DaemonSetKind]
+	for _, object := range daemonSets {
+		daemonSet := *object.(*appsv1.DaemonSet)
+		logger.Debugf("Found %s/%s", daemonSet.Kind, daemonSet.Name)
+		resource := model.DaemonSet{Delegate: daemonSet}
+		namespaceModel.AddResource(resource)
+	}
+
+	logger.Infof("=== %s/CronJobs ===", namespace)
+	cronJobs := objectsByKind[source.CronJobKind]
+	for _, object := range cronJobs {
+		cronJob := *object.(*batchv1.CronJob)
+		logger.Debugf("Found %s/%s", cronJob.Kind, cronJob.Name)
+		resource := model.CronJob{Delegate: cronJob}
+		namespaceModel.AddResource(resource)
+	}
+
+	logger.Infof("=== %s/Jobs ===", namespace)
+	jobs := objectsByKind[source.JobKind]
+	for _, object := range jobs {
+		job := *object.(*batchv1.Job)
+		logger.Debugf("Found %s/%s", job.Kind, job.Name)
+		resource := model.Job{Delegate: job}
+		namespaceModel.AddResource(resource)
+	}
+
+	logger.Infof("=== %s/ReplicaSets ===", namespace)
+	replicaSets := objectsByKind[source.ReplicaSetKind]
+	for _, object := range replicaSets {
+		replicaSet := *object.(*appsv1.ReplicaSet)
+		logger.Debugf("Found %s/%s", replicaSet.Kind, replicaSet.Name)
+		resource := model.ReplicaSet{Delegate: replicaSet}
+		if resource.IsInactive() {
+			logger.Debugf("Skipping inactive %s/%s", replicaSet.Kind, replicaSet.Name)
+			namespaceModel.SkipResource(resource)
+			continue
+		}
+		namespaceModel.AddResource(resource)
+	}
+
+	logger.Infof("=== %s/ReplicationControllers ===", namespace)
+	replicationControllers := objectsByKind[source.ReplicationControllerKind]
+	for _, object := range replicationControllers {
+		replicationController := *object.(*corev1.ReplicationController)
+		logger.Debugf("Found %s/%s", replicationController.Kind, replicationController.Name)
+		resource := model.ReplicationController{Delegate: replicationController}
+		if resource.IsInactive() {
+			logger.Debugf("Skipping inactive %s/%s", replicationController.Kind, replicationController.Name)
+			namespaceModel.SkipResource(resource)
+			continue
+		}
+		namespaceModel.AddResource(resource)
 	}
-	for _, pod := range pods.Items {
+
+	logger.Infof("=== %s/Pods ===", namespace)
+	serviceAccountsByName := make(map[string]corev1.ServiceAccount)
+	for _, object := range objectsByKind[source.ServiceAccountKind] {
+		serviceAccount := *object.(*corev1.ServiceAccount)
+		serviceAccountsByName[serviceAccount.Name] = serviceAccount
+	}
+	_, serviceAccountsFailed := errorsByKind[source.ServiceAccountKind]
+	networkPolicies := networkPoliciesOf(objectsByKind)
+	usages := podUsages(namespaceModel, objectsByKind)
+	pods := objectsByKind[source.PodKind]
+	for _, object := range pods {
+		pod := *object.(*corev1.Pod)
 		logger.Debugf("Found %s/%s with SA %s", pod.Kind, pod.Name, pod.Spec.ServiceAccountName)
-		resource := model.Pod{Delegate: pod}
-		builder.namespaceModel.AddResource(resource)
+		resource := model.Pod{Delegate: pod, Unrestricted: len(networkPolicies) > 0 && !isSelectedByAny(networkPolicies, pod)}
+		if usage, ok := usages[pod.Name]; ok {
+			utilization := model.NewUtilization(pod.Spec, usage)
+			resource.Usage = &utilization
+		}
+		namespaceModel.AddResource(resource)
 
-		serviceAccount, err := builder.coreClient.ServiceAccounts(namespace).Get(context.TODO(), pod.Spec.ServiceAccountName, metav1.GetOptions{})
-		if err != nil {
-			return err
+		if serviceAccountsFailed || pod.Spec.ServiceAccountName == "" {
+			continue
+		}
+		serviceAccount, ok := serviceAccountsByName[pod.Spec.ServiceAccountName]
+		if !ok {
+			logger.Debugf("Missing SA %s for Pod %s", pod.Spec.ServiceAccountName, pod.Name)
+			namespaceModel.AddResource(model.NewDanglingReference(source.ServiceAccountKind.Kind, namespace, pod.Spec.ServiceAccountName))
+			continue
 		}
-		saResource := model.ServiceAccount{Delegate: *serviceAccount}
-		added := builder.namespaceModel.AddResource(saResource)
+		saResource := model.ServiceAccount{Delegate: serviceAccount}
+		added := namespaceModel.AddResource(saResource)
 		if added {
 			saRoleBindings := saResource.TheRoleBindings(roleBindings)
 			for _, roleBinding := range saRoleBindings {
 				logger.Debugf("For SA %s found RoleBinding %s/%s", serviceAccount.Name, roleBinding.RoleRef.Name, roleBinding.UserNames)
 				rbResource := model.RoleBinding{Delegate: roleBinding}
-				builder.namespaceModel.AddResource(rbResource)
-				builder.namespaceModel.AddConnection(saResource, rbResource)
+				namespaceModel.AddResource(rbResource)
+				namespaceModel.AddConnection(saResource, rbResource)
 			}
 			saClusterRoleBindings := saResource.TheClusterRoleBindings(builder.clusterRoleBindings)
 			for _, clusterRoleBinding := range saClusterRoleBindings {
 				logger.Debugf("For SA %s found ClusterRoleBinding %s/%s", serviceAccount.Name, clusterRoleBinding.RoleRef.Name, clusterRoleBinding.UserNames)
 				rbResource := model.ClusterRoleBinding{Delegate: clusterRoleBinding}
-				builder.namespaceModel.AddResource(rbResource)
-				builder.namespaceModel.AddConnection(saResource, rbResource)
+				namespaceModel.AddResource(rbResource)
+				namespaceModel.AddConnection(saResource, rbResource)
 			}
 		}
 	}
 
 	if builder.exporterConfig.KNative {
-		logger.Info("=== Knative.Service ===")
-		knativeServices, err := builder.servingClient.Services(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		for _, knativeService := range knativeServices.Items {
+		logger.Infof("=== %s/Knative.Service ===", namespace)
+		knativeServices := objectsByKind[source.KnativeServiceKind]
+		for _, object := range knativeServices {
+			knativeService := *object.(*servingv1.Service)
 			logger.Debugf("Found %s/%s", knativeService.Kind, knativeService.Name)
 			resource := knative.Service{Delegate: knativeService}
-			builder.namespaceModel.AddResource(resource)
+			namespaceModel.AddResource(resource)
 		}
+		builder.addRevisions(namespaceModel, objectsByKind)
 
-		logger.Info("=== Knative.SinkBindings ===")
-		sinkBindings, err := builder.sourcesClient.SinkBindings(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		for _, sinkBinding := range sinkBindings.Items {
+		logger.Infof("=== %s/Knative.SinkBindings ===", namespace)
+		sinkBindings := objectsByKind[source.SinkBindingKind]
+		for _, object := range sinkBindings {
+			sinkBinding := *object.(*sourcesv1.SinkBinding)
 			logger.Debugf("Found %s/%s", sinkBinding.Kind, sinkBinding.Name)
 			resource := knative.SinkBinding{Delegate: sinkBinding}
-			builder.namespaceModel.AddResource(resource)
+			namespaceModel.AddResource(resource)
 		}
 
-		logger.Info("=== Knative.Brokers ===")
-		brokers, err := builder.eventingClient.Brokers(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		for _, broker := range brokers.Items {
+		logger.Infof("=== %s/Knative.Brokers ===", namespace)
+		brokers := objectsByKind[source.BrokerKind]
+		for _, object := range brokers {
+			broker := *object.(*eventingv1.Broker)
 			logger.Debugf("Found %s/%s", broker.Kind, broker.Name)
 			resource := knative.Broker{Delegate: broker}
-			builder.namespaceModel.AddResource(resource)
+			namespaceModel.AddResource(resource)
 		}
 
-		logger.Info("=== Knative.Triggers ===")
-		triggers, err := builder.eventingClient.Triggers(namespace).List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return err
-		}
-		for _, trigger := range triggers.Items {
+		logger.Infof("=== %s/Knative.Triggers ===", namespace)
+		triggers := objectsByKind[source.TriggerKind]
+		for _, object := range triggers {
+			trigger := *object.(*eventingv1.Trigger)
 			logger.Debugf("Found %s/%s", trigger.Kind, trigger.Name)
 			resource := knative.Trigger{Delegate: trigger}
-			builder.namespaceModel.AddResource(resource)
+			namespaceModel.AddResource(resource)
+		}
+		builder.addChannels(namespaceModel, objectsByKind)
+		builder.addEventSources(namespaceModel, objectsByKind)
+	}
+	builder.addBuilds(namespaceModel, objectsByKind)
+	builder.addConfigReferences(namespaceModel, objectsByKind, errorsByKind)
+	builder.addStorage(namespaceModel, objectsByKind, errorsByKind)
+	builder.addIngresses(namespaceModel, objectsByKind)
+	builder.addCustomResources(namespaceModel, objectsByKind)
+	builder.removeOwnedBySkipped(namespaceModel)
+	if builder.exporterConfig.KNative {
+		builder.addDestinations(namespaceModel)
+	}
+	builder.addOwners(namespaceModel)
+	if builder.exporterConfig.Events.Enabled {
+		builder.addEvents(namespaceModel, objectsByKind)
+	}
+	// Before collapsing, to find the controllers of the Pods through their ReplicaSets
+	builder.addNetworkPolicies(namespaceModel, networkPolicies)
+	if builder.exporterConfig.CollapseReplicaSets {
+		builder.collapseReplicaSets(namespaceModel)
+	}
+	builder.connectResources(namespaceModel)
+	switch builder.exporterConfig.DetailLevelOrDefault() {
+	case config.WorkloadsDetailLevel:
+		builder.aggregateWorkloads(namespaceModel, true)
+	case config.ApplicationsDetailLevel:
+		builder.aggregateWorkloads(namespaceModel, true)
+		builder.aggregateApplications(namespaceModel)
+	default:
+		if builder.exporterConfig.Metrics.Enabled {
+			// Only to sum the usage of the Pods into their workloads
+			builder.aggregateWorkloads(namespaceModel, false)
 		}
 	}
-	builder.addOwners()
-	builder.connectResources()
 
+	logger.Infof("Built NS %s in %s (listing took %s): %d resources, %d connections", namespace, time.Since(start), listDuration,
+		len(namespaceModel.AllResources()), len(namespaceModel.AllConnections()))
 	return nil
 }
 
-func (builder *ModelBuilder) connectResources() {
-	for _, kind := range builder.namespaceModel.AllKinds() {
-		for _, fromResource := range builder.namespaceModel.ResourcesByKind(kind) {
+func (builder *ModelBuilder) connectResources(namespaceModel *model.NamespaceModel) {
+	for _, kind := range namespaceModel.AllKinds() {
+		for _, fromResource := range namespaceModel.ResourcesByKind(kind) {
 			for _, kind := range fromResource.ConnectedKinds() {
-				potentialTos := builder.namespaceModel.ResourcesByKind(kind)
+				potentialTos := namespaceModel.ResourcesByKind(kind)
 				connectedResources, connectionName := fromResource.ConnectedResources(kind, potentialTos)
 				for _, connectedResource := range connectedResources {
+					connectionName := connectionName
+					if namedConnector, ok := fromResource.(model.NamedConnector); ok {
+						if name := namedConnector.ConnectionName(connectedResource); name != "" {
+							connectionName = name
+						}
+					}
 					logger.Debugf("Connecting %s of kind %s to %s of kind %s with name %s",
 						fromResource.Label(), fromResource.Kind(), connectedResource.Label(), connectedResource.Kind(), connectionName)
 					if connectionName != "" {
-						builder.namespaceModel.AddNamedConnection(fromResource, connectedResource, connectionName)
+						namespaceModel.AddNamedConnection(fromResource, connectedResource, connectionName)
 					} else {
-						builder.namespaceModel.AddConnection(fromResource, connectedResource)
+						namespaceModel.AddConnection(fromResource, connectedResource)
 					}
-					builder.namespaceModel.AllConnections()
+					namespaceModel.AllConnections()
 				}
 			}
 		}
 	}
 }
-
-func (builder *ModelBuilder) addOwners() {
-	for _, kind := range builder.namespaceModel.AllKinds() {
-		resourcesByKind := builder.namespaceModel.ResourcesByKind(kind)
-		for _, resource := range resourcesByKind {
-			for _, owner := range resource.OwnerReferences() {
-				logger.Debugf("Adding ownership of %s of kind %s to %s of kind %s",
-					resource.Label(), resource.Kind(), owner.Name, owner.Kind)
-				ownerResource := builder.namespaceModel.LookupOwner(owner)
-				builder.namespaceModel.AddNamedConnection(ownerResource, resource, "owns")
-				builder.namespaceModel.AllConnections()
-			}
-		}
-	}
-}
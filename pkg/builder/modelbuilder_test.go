@@ -0,0 +1,170 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/dmartinol/openshift-topology-exporter/pkg/config"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const testNamespace = "ns1"
+
+func objectMeta(name string, uid string, labels map[string]string, owners ...metav1.OwnerReference) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: testNamespace, UID: types.UID(uid), Labels: labels, OwnerReferences: owners}
+}
+
+func controllerRef(apiVersion string, kind string, name string, uid string) metav1.OwnerReference {
+	controller := true
+	return metav1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: name, UID: types.UID(uid), Controller: &controller}
+}
+
+func runningPod(name string, labels map[string]string, ready bool, owners ...metav1.OwnerReference) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{ObjectMeta: objectMeta(name, "", labels, owners...),
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}}}
+}
+
+// buildTestNamespace builds the test namespace from the given objects
+func buildTestNamespace(t *testing.T, exporterConfig config.ExporterConfig, objects ...runtime.Object) *model.NamespaceModel {
+	t.Helper()
+	memorySource, err := source.NewMemorySource(objects...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exporterConfig.Namespaces = []string{testNamespace}
+	topology, err := NewModelBuilder(exporterConfig).Build(memorySource)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return topology.NamespaceByName(testNamespace)
+}
+
+// connectionsOf formats the connections of the namespace like "deployment api -> rs api-1 (owns)"
+func connectionsOf(namespaceModel *model.NamespaceModel) map[string]bool {
+	connections := make(map[string]bool)
+	for _, connection := range namespaceModel.AllConnections() {
+		name := fmt.Sprintf("%s -> %s", connection.From.Id(), connection.To.Id())
+		if connection.Name != "" {
+			name = fmt.Sprintf("%s (%s)", name, connection.Name)
+		}
+		connections[name] = true
+	}
+	return connections
+}
+
+func labelOf(namespaceModel *model.NamespaceModel, kind string, id string) string {
+	resource := namespaceModel.LookupByKindAndId(kind, id)
+	if resource == nil {
+		return ""
+	}
+	return resource.Label()
+}
+
+func assertConnections(t *testing.T, namespaceModel *model.NamespaceModel, want []string, unwanted []string) {
+	t.Helper()
+	connections := connectionsOf(namespaceModel)
+	for _, connection := range want {
+		if !connections[connection] {
+			t.Errorf("missing connection %s in %v", connection, connections)
+		}
+	}
+	for _, connection := range unwanted {
+		if connections[connection] {
+			t.Errorf("unexpected connection %s", connection)
+		}
+	}
+}
+
+// failingSource fails to list the given kind in the given namespace, and counts the listings of each namespace
+type failingSource struct {
+	*source.MemorySource
+	failingKind      schema.GroupVersionKind
+	failingNamespace string
+	lock             sync.Mutex
+	listings         map[string]int
+}
+
+func newFailingSource(t *testing.T, failingKind schema.GroupVersionKind, failingNamespace string) *failingSource {
+	t.Helper()
+	memorySource, err := source.NewMemorySource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &failingSource{MemorySource: memorySource, failingKind: failingKind, failingNamespace: failingNamespace,
+		listings: make(map[string]int)}
+}
+
+func (s *failingSource) List(kind schema.GroupVersionKind, namespace string) ([]runtime.Object, error) {
+	s.lock.Lock()
+	s.listings[namespace]++
+	s.lock.Unlock()
+	if kind == s.failingKind && namespace == s.failingNamespace {
+		return nil, errors.New("forbidden")
+	}
+	return s.MemorySource.List(kind, namespace)
+}
+
+func TestStrictMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		strict       bool
+		wantErr      bool
+		wantListings bool
+	}{
+		{"continues after a failure", false, false, true},
+		{"stops at the first failure", true, true, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			failing := newFailingSource(t, source.PodKind, "ns1")
+			exporterConfig := config.ExporterConfig{Namespaces: []string{"ns1", "ns2", "ns3"}, Strict: test.strict, Parallelism: 1}
+			_, err := NewModelBuilder(exporterConfig).Build(failing)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if listed := failing.listings["ns2"] > 0 || failing.listings["ns3"] > 0; listed != test.wantListings {
+				t.Errorf("listed the other namespaces = %v, want %v", listed, test.wantListings)
+			}
+		})
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		name                  string
+		failingKind           schema.GroupVersionKind
+		failingNamespace      string
+		wantClusterWarnings   int
+		wantNamespaceWarnings int
+	}{
+		{"namespaced kind", source.PodKind, "ns1", 0, 1},
+		{"cluster-scoped kind", source.ClusterRoleBindingKind, "", 1, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			failing := newFailingSource(t, test.failingKind, test.failingNamespace)
+			topology, err := NewModelBuilder(config.ExporterConfig{Namespaces: []string{"ns1"}}).Build(failing)
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if got := len(topology.Warnings()); got != test.wantClusterWarnings {
+				t.Errorf("cluster warnings = %v, want %d", topology.Warnings(), test.wantClusterWarnings)
+			}
+			if got := len(topology.NamespaceByName("ns1").Warnings()); got != test.wantNamespaceWarnings {
+				t.Errorf("namespace warnings = %v, want %d", topology.NamespaceByName("ns1").Warnings(), test.wantNamespaceWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package builder
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// namePattern matches a namespace name with a glob pattern or a regular expression enclosed in slashes
+type namePattern struct {
+	glob  string
+	regex *regexp.Regexp
+}
+
+func newNamePattern(pattern string) (namePattern, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		regex, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return namePattern{}, fmt.Errorf("invalid namespace pattern %s: %v", pattern, err)
+		}
+		return namePattern{regex: regex}, nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return namePattern{}, fmt.Errorf("invalid namespace pattern %s: %v", pattern, err)
+	}
+	return namePattern{glob: pattern}, nil
+}
+
+func (pattern namePattern) matches(name string) bool {
+	if pattern.regex != nil {
+		return pattern.regex.MatchString(name)
+	}
+	matched, _ := path.Match(pattern.glob, name)
+	return matched
+}
+
+func newNamePatterns(patterns []string) ([]namePattern, error) {
+	namePatterns := make([]namePattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		namePattern, err := newNamePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		namePatterns = append(namePatterns, namePattern)
+	}
+	return namePatterns, nil
+}
+
+func matchesAny(patterns []namePattern, name string) bool {
+	for _, pattern := range patterns {
+		if pattern.matches(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// discoverNamespaces adds to the configured namespaces the ones selected by the NamespaceSelector, and removes the
+// excluded ones. A selector with only excludes selects all the candidate namespaces when no namespaces are configured,
+// and filters the configured ones otherwise
+func (builder *ModelBuilder) discoverNamespaces() error {
+	selector := builder.exporterConfig.NamespaceSelector
+	if !selector.IsEnabled() {
+		return nil
+	}
+	labelSelector, err := labels.Parse(selector.Labels)
+	if err != nil {
+		return fmt.Errorf("invalid namespace label selector %s: %v", selector.Labels, err)
+	}
+	includes, err := newNamePatterns(selector.Include)
+	if err != nil {
+		return err
+	}
+	excludes, err := newNamePatterns(selector.Exclude)
+	if err != nil {
+		return err
+	}
+
+	filterOnly := selector.IsExcludeOnly() && len(builder.exporterConfig.Namespaces) > 0
+	configured := make([]string, 0, len(builder.exporterConfig.Namespaces))
+	for _, name := range builder.exporterConfig.Namespaces {
+		if matchesAny(excludes, name) {
+			logger.Debugf("Excluded NS %s", name)
+			continue
+		}
+		configured = append(configured, name)
+	}
+	builder.exporterConfig.Namespaces = configured
+	if filterOnly {
+		return nil
+	}
+
+	candidates, err := builder.candidateNamespaces()
+	if err != nil {
+		return err
+	}
+	discovered := make([]string, 0)
+	for _, candidate := range candidates {
+		accessor, err := meta.Accessor(candidate)
+		if err != nil {
+			return err
+		}
+		name := accessor.GetName()
+		if !labelSelector.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		if len(includes) > 0 && !matchesAny(includes, name) {
+			continue
+		}
+		if matchesAny(excludes, name) {
+			logger.Debugf("Excluded NS %s", name)
+			continue
+		}
+		discovered = append(discovered, name)
+	}
+	sort.Strings(discovered)
+	logger.Infof("Discovered %d namespaces: %s", len(discovered), discovered)
+
+	for _, name := range discovered {
+		if !contains(builder.exporterConfig.Namespaces, name) {
+			builder.exporterConfig.Namespaces = append(builder.exporterConfig.Namespaces, name)
+		}
+	}
+	return nil
+}
+
+// candidateNamespaces returns the projects visible to the current user, or all the namespaces when the
+// Project API is not available
+func (builder *ModelBuilder) candidateNamespaces() ([]runtime.Object, error) {
+	projects, err := builder.source.List(source.ProjectKind, "")
+	if err == nil && len(projects) > 0 {
+		return projects, nil
+	}
+	if err != nil {
+		logger.Debugf("Cannot list projects, listing namespaces instead: %v", err)
+	}
+	return builder.source.List(source.NamespaceKind, "")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// maxOwnerDepth bounds the walk up the ownership chain, in case of cyclic references
+const maxOwnerDepth = 10
+
+func networkPoliciesOf(objectsByKind map[schema.GroupVersionKind][]runtime.Object) []networkingv1.NetworkPolicy {
+	networkPolicies := make([]networkingv1.NetworkPolicy, 0)
+	for _, object := range objectsByKind[source.NetworkPolicyKind] {
+		networkPolicies = append(networkPolicies, *object.(*networkingv1.NetworkPolicy))
+	}
+	return networkPolicies
+}
+
+func isSelectedByAny(networkPolicies []networkingv1.NetworkPolicy, pod corev1.Pod) bool {
+	for _, networkPolicy := range networkPolicies {
+		if (model.NetworkPolicy{Delegate: networkPolicy}).Selects(pod.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedTraffic collects the traffic allowed from a workload to another one
+type allowedTraffic struct {
+	from    model.Resource
+	to      model.Resource
+	ingress []string
+	egress  []string
+}
+
+func (traffic *allowedTraffic) name() string {
+	parts := make([]string, 0, 2)
+	if len(traffic.ingress) > 0 {
+		parts = append(parts, fmt.Sprintf("ingress %s", strings.Join(traffic.ingress, ", ")))
+	}
+	if len(traffic.egress) > 0 {
+		parts = append(parts, fmt.Sprintf("egress %s", strings.Join(traffic.egress, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// addNetworkPolicies adds the NetworkPolicies of the namespace, connected to the workloads they apply to, and the effective
+// traffic they allow between the workloads of the namespace: the traffic must be allowed both by the egress policies of the
+// source, if any, and by the ingress policies of the destination, if any. Peers in other namespaces and IP blocks are not drawn
+func (builder *ModelBuilder) addNetworkPolicies(namespaceModel *model.NamespaceModel, networkPolicies []networkingv1.NetworkPolicy) {
+	if len(networkPolicies) == 0 {
+		return
+	}
+	logger.Infof("=== %s/NetworkPolicies ===", namespaceModel.Name())
+	pods := make([]model.Pod, 0)
+	// The workloads are resolved once, as looking up the owners scans all the resources of the namespace
+	workloads := make(map[string]model.Resource)
+	for _, resource := range namespaceModel.ResourcesByKind(model.Pod{}.Kind()) {
+		pod, ok := resource.(model.Pod)
+		if !ok {
+			continue
+		}
+		pods = append(pods, pod)
+		workloads[pod.Id()] = workloadOf(namespaceModel, pod)
+	}
+	namespaceLabels := builder.namespaceLabels(namespaceModel.Name())
+
+	ingressPolicies := make(map[string][]model.NetworkPolicy)
+	egressPolicies := make(map[string][]model.NetworkPolicy)
+	for _, networkPolicy := range networkPolicies {
+		logger.Debugf("Found %s/%s", networkPolicy.Kind, networkPolicy.Name)
+		resource := model.NetworkPolicy{Delegate: networkPolicy}
+		namespaceModel.AddResource(resource)
+		for _, pod := range pods {
+			if !resource.Selects(pod.Labels()) {
+				continue
+			}
+			namespaceModel.AddNamedConnection(resource, workloads[pod.Id()], "applies to")
+			if resource.AppliesTo(networkingv1.PolicyTypeIngress) {
+				ingressPolicies[pod.Id()] = append(ingressPolicies[pod.Id()], resource)
+			}
+			if resource.AppliesTo(networkingv1.PolicyTypeEgress) {
+				egressPolicies[pod.Id()] = append(egressPolicies[pod.Id()], resource)
+			}
+		}
+	}
+
+	allowed := make([]*allowedTraffic, 0)
+	allow := func(from model.Resource, to model.Resource, ingressPorts []string, egressPorts []string) {
+		if from.Kind() == to.Kind() && from.Id() == to.Id() {
+			return
+		}
+		var traffic *allowedTraffic
+		for _, t := range allowed {
+			if t.from.Id() == from.Id() && t.to.Id() == to.Id() {
+				traffic = t
+			}
+		}
+		if traffic == nil {
+			traffic = &allowedTraffic{from: from, to: to}
+			allowed = append(allowed, traffic)
+		}
+		for _, ports := range ingressPorts {
+			if !contains(traffic.ingress, ports) {
+				traffic.ingress = append(traffic.ingress, ports)
+			}
+		}
+		for _, ports := range egressPorts {
+			if !contains(traffic.egress, ports) {
+				traffic.egress = append(traffic.egress, ports)
+			}
+		}
+	}
+
+	for _, from := range pods {
+		for _, to := range pods {
+			// The traffic between Pods that are not isolated by any policy is not drawn
+			if len(egressPolicies[from.Id()]) == 0 && len(ingressPolicies[to.Id()]) == 0 {
+				continue
+			}
+			egressPorts, egressAllowed := allowedPorts(egressPolicies[from.Id()], networkingv1.PolicyTypeEgress, to, namespaceLabels)
+			ingressPorts, ingressAllowed := allowedPorts(ingressPolicies[to.Id()], networkingv1.PolicyTypeIngress, from, namespaceLabels)
+			if egressAllowed && ingressAllowed {
+				allow(workloads[from.Id()], workloads[to.Id()], ingressPorts, egressPorts)
+			}
+		}
+	}
+	for _, traffic := range allowed {
+		namespaceModel.AddNamedConnection(traffic.from, traffic.to, traffic.name())
+	}
+}
+
+// allowedPorts returns the ports of the rules of the given policies that allow the traffic with the peer Pod, and
+// whether the traffic is allowed: always when no policies isolate the Pod in that direction
+func allowedPorts(networkPolicies []model.NetworkPolicy, policyType networkingv1.PolicyType, peer model.Pod,
+	namespaceLabels map[string]string) ([]string, bool) {
+	if len(networkPolicies) == 0 {
+		return []string{}, true
+	}
+	ports := make([]string, 0)
+	for _, networkPolicy := range networkPolicies {
+		if policyType == networkingv1.PolicyTypeIngress {
+			for _, rule := range networkPolicy.Delegate.Spec.Ingress {
+				if allowsPod(rule.From, peer, namespaceLabels) && !contains(ports, model.PortsLabel(rule.Ports)) {
+					ports = append(ports, model.PortsLabel(rule.Ports))
+				}
+			}
+		} else {
+			for _, rule := range networkPolicy.Delegate.Spec.Egress {
+				if allowsPod(rule.To, peer, namespaceLabels) && !contains(ports, model.PortsLabel(rule.Ports)) {
+					ports = append(ports, model.PortsLabel(rule.Ports))
+				}
+			}
+		}
+	}
+	return ports, len(ports) > 0
+}
+
+// allowsPod returns true if the peers of a rule include the given Pod of the namespace. No peers means all sources
+// or destinations
+func allowsPod(peers []networkingv1.NetworkPolicyPeer, pod model.Pod, namespaceLabels map[string]string) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		if model.AllowsPeer(peer, pod.Labels(), namespaceLabels, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// workloadOf returns the top-level controller of the given resource, like the Deployment of a Pod, or the resource
+// itself when it has no collected controller
+func workloadOf(namespaceModel *model.NamespaceModel, resource model.Resource) model.Resource {
+	for depth := 0; depth < maxOwnerDepth; depth++ {
+		controller := controllerOf(namespaceModel, resource)
+		if controller == nil {
+			return resource
+		}
+		resource = controller
+	}
+	return resource
+}
+
+// controllerOf returns the collected controller of the given resource, or nil
+func controllerOf(namespaceModel *model.NamespaceModel, resource model.Resource) model.Resource {
+	for _, owner := range resource.OwnerReferences() {
+		if owner.Controller != nil && *owner.Controller {
+			return namespaceModel.LookupOwner(owner)
+		}
+	}
+	return nil
+}
+
+// namespaceLabels returns the labels of the given namespace, including the kubernetes.io/metadata.name label that
+// is not set by the clusters older than 1.21 or in the exported manifests
+func (builder *ModelBuilder) namespaceLabels(namespace string) map[string]string {
+	namespaceLabels := map[string]string{corev1.LabelMetadataName: namespace}
+	builder.requests <- struct{}{}
+	object, err := builder.source.Get(source.NamespaceKind, "", namespace)
+	<-builder.requests
+	if err != nil {
+		logger.Debugf("Cannot get the labels of NS %s: %v", namespace, err)
+		return namespaceLabels
+	}
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return namespaceLabels
+	}
+	for key, value := range accessor.GetLabels() {
+		namespaceLabels[key] = value
+	}
+	return namespaceLabels
+}
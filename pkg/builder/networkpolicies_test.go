@@ -0,0 +1,58 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/dmartinol/openshift-topology-exporter/pkg/config"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func networkPolicy(name string, app string, policyType networkingv1.PolicyType, peerApp string, port int) *networkingv1.NetworkPolicy {
+	peers := []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": peerApp}}}}
+	ports := []networkingv1.NetworkPolicyPort{{Port: &intstr.IntOrString{IntVal: int32(port)}}}
+	networkPolicy := &networkingv1.NetworkPolicy{ObjectMeta: objectMeta(name, "", nil), Spec: networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+		PolicyTypes: []networkingv1.PolicyType{policyType}}}
+	if policyType == networkingv1.PolicyTypeIngress {
+		networkPolicy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{From: peers, Ports: ports}}
+	} else {
+		networkPolicy.Spec.Egress = []networkingv1.NetworkPolicyEgressRule{{To: peers, Ports: ports}}
+	}
+	return networkPolicy
+}
+
+func TestNetworkPolicies(t *testing.T) {
+	pods := []runtime.Object{
+		runningPod("fe", map[string]string{"app": "fe"}, true),
+		runningPod("be", map[string]string{"app": "be"}, true),
+		runningPod("db", map[string]string{"app": "db"}, true),
+	}
+	beIngress := networkPolicy("be-ingress", "be", networkingv1.PolicyTypeIngress, "fe", 8080)
+	dbIngress := networkPolicy("db-ingress", "db", networkingv1.PolicyTypeIngress, "be", 5432)
+	feEgress := networkPolicy("fe-egress", "fe", networkingv1.PolicyTypeEgress, "db", 5432)
+	tests := []struct {
+		name     string
+		policies []runtime.Object
+		want     []string
+		unwanted []string
+	}{
+		{"ingress policies", []runtime.Object{beIngress, dbIngress},
+			[]string{"netpol be-ingress -> pod be (applies to)", "pod fe -> pod be (ingress TCP/8080)", "pod be -> pod db (ingress TCP/5432)"},
+			[]string{"pod fe -> pod db (ingress TCP/5432)", "pod be -> pod fe (ingress TCP/8080)"}},
+		{"denied by the egress of the source", []runtime.Object{beIngress, dbIngress, feEgress},
+			[]string{"netpol fe-egress -> pod fe (applies to)", "pod be -> pod db (ingress TCP/5432)"},
+			[]string{"pod fe -> pod be (ingress TCP/8080)", "pod fe -> pod db (egress TCP/5432)"}},
+		{"allowed by the egress only", []runtime.Object{feEgress},
+			[]string{"pod fe -> pod db (egress TCP/5432)"},
+			[]string{"pod fe -> pod be (egress TCP/5432)", "pod be -> pod db (egress TCP/5432)"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			namespaceModel := buildTestNamespace(t, config.ExporterConfig{}, append(test.policies, pods...)...)
+			assertConnections(t, namespaceModel, test.want, test.unwanted)
+		})
+	}
+}
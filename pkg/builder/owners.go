@@ -0,0 +1,129 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// addOwners connects the resources to their owners, walking the ownership chain up to the root controller.
+// The owners that were not collected are fetched from the Source, the ones that cannot be fetched are marked as unresolved
+func (builder *ModelBuilder) addOwners(namespaceModel *model.NamespaceModel) {
+	pending := namespaceModel.AllResources()
+	for len(pending) > 0 {
+		resource := pending[0]
+		pending = pending[1:]
+		for _, owner := range resource.OwnerReferences() {
+			logger.Debugf("Adding ownership of %s of kind %s to %s of kind %s",
+				resource.Label(), resource.Kind(), owner.Name, owner.Kind)
+			ownerResource := namespaceModel.LookupOwner(owner)
+			if ownerResource == nil {
+				ownerResource = builder.resolveOwner(namespaceModel.Name(), owner)
+				if namespaceModel.AddResource(ownerResource) {
+					pending = append(pending, ownerResource)
+				}
+			}
+			namespaceModel.AddNamedConnection(ownerResource, resource, "owns")
+		}
+	}
+}
+
+// removeOwnedBySkipped removes the resources owned, directly or through other owners, by the resources left out of the
+// topology on purpose, like the Deployments and Pods of the inactive Revisions, so that addOwners does not add their
+// skipped owners back
+func (builder *ModelBuilder) removeOwnedBySkipped(namespaceModel *model.NamespaceModel) {
+	for removed := true; removed; {
+		removed = false
+		for _, resource := range namespaceModel.AllResources() {
+			for _, owner := range resource.OwnerReferences() {
+				if namespaceModel.IsSkippedOwner(owner) {
+					logger.Debugf("Skipping %s of kind %s owned by skipped %s of kind %s",
+						resource.Name(), resource.Kind(), owner.Name, owner.Kind)
+					namespaceModel.RemoveResource(resource)
+					namespaceModel.SkipResource(resource)
+					removed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+func (builder *ModelBuilder) resolveOwner(namespace string, owner metav1.OwnerReference) model.Resource {
+	object, err := builder.fetchOwner(namespace, owner)
+	if err != nil {
+		logger.Infof("Cannot resolve owner %s of kind %s: %v", owner.Name, owner.Kind, err)
+		if strings.Compare(owner.Kind, "ClusterServiceVersion") == 0 {
+			return model.ClusterServiceVersion{Delegate: owner}
+		}
+		return model.CustomResource{Delegate: owner}
+	}
+
+	logger.Debugf("Resolved owner %s of kind %s", owner.Name, owner.Kind)
+	resource := model.UnstructuredResource{Delegate: *object}
+	if strings.Compare(owner.Kind, "ClusterServiceVersion") == 0 {
+		resource.IconPath = "images/operator.png"
+	}
+	return resource
+}
+
+func (builder *ModelBuilder) fetchOwner(namespace string, owner metav1.OwnerReference) (*unstructured.Unstructured, error) {
+	groupVersion, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	kind := groupVersion.WithKind(owner.Kind)
+	builder.requests <- struct{}{}
+	object, err := builder.source.Get(kind, namespace, owner.Name)
+	<-builder.requests
+	if err != nil {
+		return nil, err
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, err
+	}
+	if owner.UID != "" && accessor.GetUID() != "" && accessor.GetUID() != owner.UID {
+		return nil, fmt.Errorf("found UID %s instead of %s", accessor.GetUID(), owner.UID)
+	}
+	return asUnstructured(object, kind)
+}
+
+// collapseReplicaSets replaces the owned ReplicaSets and ReplicationControllers with direct connections
+// from their owners to their Pods
+func (builder *ModelBuilder) collapseReplicaSets(namespaceModel *model.NamespaceModel) {
+	replicas := append(namespaceModel.ResourcesByKind(model.ReplicaSet{}.Kind()),
+		namespaceModel.ResourcesByKind(model.ReplicationController{}.Kind())...)
+	connections := namespaceModel.AllConnections()
+	for _, replica := range replicas {
+		owners := make([]model.Resource, 0)
+		owned := make([]model.Resource, 0)
+		for _, connection := range connections {
+			if connection.Name != "owns" {
+				continue
+			}
+			if connection.To.Kind() == replica.Kind() && connection.To.Id() == replica.Id() {
+				owners = append(owners, connection.From)
+			} else if connection.From.Kind() == replica.Kind() && connection.From.Id() == replica.Id() {
+				owned = append(owned, connection.To)
+			}
+		}
+		if len(owners) == 0 {
+			continue
+		}
+		logger.Debugf("Collapsing %s of kind %s", replica.Name(), replica.Kind())
+		namespaceModel.RemoveResource(replica)
+		for _, owner := range owners {
+			for _, resource := range owned {
+				namespaceModel.AddNamedConnection(owner, resource, "owns")
+			}
+		}
+	}
+}
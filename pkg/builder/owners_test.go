@@ -0,0 +1,79 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/dmartinol/openshift-topology-exporter/pkg/config"
+	appsv1T "github.com/openshift/api/apps/v1"
+	routev1T "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func TestOwners(t *testing.T) {
+	// Owners of other API groups sharing the kind of the collected resources, like the Knative Services
+	knativeService := &servingv1.Service{ObjectMeta: objectMeta("api", "ks1", nil)}
+	configuration := &servingv1.Configuration{ObjectMeta: objectMeta("api", "kc1", nil,
+		controllerRef("serving.knative.dev/v1", "Service", "api", "ks1"))}
+	revision := &servingv1.Revision{ObjectMeta: objectMeta("api-1", "rev1", nil,
+		controllerRef("serving.knative.dev/v1", "Configuration", "api", "kc1"))}
+	revisionDeployment := &appsv1.Deployment{ObjectMeta: objectMeta("api-1-deployment", "d1", nil,
+		controllerRef("serving.knative.dev/v1", "Revision", "api-1", "rev1"))}
+	service := &corev1.Service{ObjectMeta: objectMeta("api", "", nil)}
+	route := &routev1T.Route{ObjectMeta: objectMeta("api", "", nil),
+		Spec: routev1T.RouteSpec{To: routev1T.RouteTargetReference{Kind: "Service", Name: "api"}}}
+
+	// The UIDs of the owners, matched by name and kind for the manifests exported without UIDs
+	deployment := &appsv1.Deployment{ObjectMeta: objectMeta("web", "wd1", nil)}
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: objectMeta("web-1", "wr1", nil, controllerRef("apps/v1", "Deployment", "web", "wd1"))}
+	// The inactive ReplicationControllers of the previous rollouts of a DeploymentConfig, and their leftover deployer Pods
+	replicas := int32(0)
+	deploymentConfig := &appsv1T.DeploymentConfig{ObjectMeta: objectMeta("app", "dc1", nil)}
+	deploymentConfigRef := controllerRef("apps.openshift.io/v1", "DeploymentConfig", "app", "dc1")
+	inactiveReplicationController := &corev1.ReplicationController{ObjectMeta: objectMeta("app-1", "rc1", nil, deploymentConfigRef),
+		Spec: corev1.ReplicationControllerSpec{Replicas: &replicas}}
+	replicationController := &corev1.ReplicationController{ObjectMeta: objectMeta("app-2", "rc2", nil, deploymentConfigRef)}
+
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		want     []string
+		unwanted []string
+	}{
+		{"resolved owners", []runtime.Object{knativeService, configuration, revision, revisionDeployment, service, route},
+			[]string{
+				"serving.knative.dev/service api -> serving.knative.dev/configuration api (owns)",
+				"serving.knative.dev/configuration api -> serving.knative.dev/revision api-1 (owns)",
+				"serving.knative.dev/revision api-1 -> deployment api-1-deployment (owns)",
+				"route api -> svc api (exposed)",
+			}, nil},
+		{"unresolved owner", []runtime.Object{runningPod("worker", nil, true, controllerRef("example.com/v1", "Widget", "w", "w1"))},
+			[]string{"example.com/widget w -> pod worker (owns)"}, nil},
+		{"unresolved owner sharing a collected kind", []runtime.Object{service, route,
+			runningPod("worker", nil, true, controllerRef("example.com/v1", "Service", "api", "s1"))},
+			[]string{"example.com/service api -> pod worker (owns)", "route api -> svc api (exposed)"}, nil},
+		{"matched by UID", []runtime.Object{deployment, replicaSet,
+			runningPod("web-1-x", nil, true, controllerRef("apps/v1", "ReplicaSet", "web-1", "wr1"))},
+			[]string{"deployment web -> rs web-1 (owns)", "rs web-1 -> pod web-1-x (owns)"}, nil},
+		{"matched by name without UID", []runtime.Object{deployment, replicaSet,
+			runningPod("web-1-x", nil, true, controllerRef("apps/v1", "ReplicaSet", "web-1", ""))},
+			[]string{"rs web-1 -> pod web-1-x (owns)"}, nil},
+		{"other UID", []runtime.Object{deployment, replicaSet,
+			runningPod("web-1-x", nil, true, controllerRef("apps/v1", "ReplicaSet", "web-1", "wr2"))},
+			[]string{"apps/replicaset web-1 -> pod web-1-x (owns)"}, []string{"rs web-1 -> pod web-1-x (owns)"}},
+		{"inactive ReplicationController", []runtime.Object{deploymentConfig, inactiveReplicationController, replicationController,
+			runningPod("app-1-deploy", nil, false, controllerRef("v1", "ReplicationController", "app-1", "rc1")),
+			runningPod("app-2-x", nil, true, controllerRef("v1", "ReplicationController", "app-2", "rc2"))},
+			[]string{"deploymentconfig app -> rc app-2 (owns)", "rc app-2 -> pod app-2-x (owns)"},
+			[]string{"deploymentconfig app -> core/replicationcontroller app-1 (owns)", "core/replicationcontroller app-1 -> pod app-1-deploy (owns)",
+				"deploymentconfig app -> rc app-1 (owns)"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			namespaceModel := buildTestNamespace(t, config.ExporterConfig{}, test.objects...)
+			assertConnections(t, namespaceModel, test.want, test.unwanted)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package builder
+
+import (
+	"fmt"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"github.com/dmartinol/openshift-topology-exporter/pkg/source"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// initStorage lists the cluster-scoped PersistentVolumes and StorageClasses, that are added to the topology
+// only when bound to the claims of the collected namespaces
+func (builder *ModelBuilder) initStorage() error {
+	builder.persistentVolumes = make(map[string]corev1.PersistentVolume)
+	builder.storageClasses = make(map[string]storagev1.StorageClass)
+
+	persistentVolumes, err := builder.listClusterScoped(source.PersistentVolumeKind)
+	if err != nil {
+		return err
+	}
+	for _, object := range persistentVolumes {
+		persistentVolume := *object.(*corev1.PersistentVolume)
+		builder.persistentVolumes[persistentVolume.Name] = persistentVolume
+	}
+	storageClasses, err := builder.listClusterScoped(source.StorageClassKind)
+	if err != nil {
+		return err
+	}
+	for _, object := range storageClasses {
+		storageClass := *object.(*storagev1.StorageClass)
+		builder.storageClasses[storageClass.Name] = storageClass
+	}
+	logger.Debugf("Found %d PersistentVolumes and %d StorageClasses", len(builder.persistentVolumes), len(builder.storageClasses))
+	return nil
+}
+
+// listClusterScoped lists the given cluster-scoped kind, recording a warning on failure unless running in strict mode
+func (builder *ModelBuilder) listClusterScoped(kind schema.GroupVersionKind) ([]runtime.Object, error) {
+	objects, err := builder.source.List(kind, "")
+	if err != nil && !isNotServed(kind, err) {
+		if builder.exporterConfig.Strict {
+			return nil, err
+		}
+		builder.topologyModel.AddWarning(fmt.Sprintf("Skipped %s: %v", kind.GroupKind(), err))
+		return []runtime.Object{}, nil
+	}
+	return objects, nil
+}
+
+// addStorage adds the PersistentVolumeClaims of the namespace, connected to their cluster-scoped PersistentVolume
+// and StorageClass, and a DanglingReference for the claims used by the Pods that do not exist
+func (builder *ModelBuilder) addStorage(namespaceModel *model.NamespaceModel,
+	objectsByKind map[schema.GroupVersionKind][]runtime.Object, errorsByKind map[schema.GroupVersionKind]error) {
+	logger.Infof("=== %s/PersistentVolumeClaims ===", namespaceModel.Name())
+	clusterScoped := builder.topologyModel.ClusterScoped()
+	claimNames := make([]string, 0)
+	for _, object := range objectsByKind[source.PersistentVolumeClaimKind] {
+		claim := *object.(*corev1.PersistentVolumeClaim)
+		logger.Debugf("Found %s/%s", claim.Kind, claim.Name)
+		resource := model.PersistentVolumeClaim{Delegate: claim}
+		namespaceModel.AddResource(resource)
+		claimNames = append(claimNames, claim.Name)
+
+		persistentVolume, ok := builder.persistentVolumes[claim.Spec.VolumeName]
+		if !ok {
+			continue
+		}
+		pvResource := model.PersistentVolume{Delegate: persistentVolume}
+		clusterScoped.AddResource(pvResource)
+		clusterScoped.AddConnection(resource, pvResource)
+		if storageClass, ok := builder.storageClasses[persistentVolume.Spec.StorageClassName]; ok {
+			clusterScoped.AddResource(model.StorageClass{Delegate: storageClass})
+		}
+	}
+
+	if _, failed := errorsByKind[source.PersistentVolumeClaimKind]; failed {
+		return
+	}
+	for _, resource := range namespaceModel.ResourcesByKind(model.Pod{}.Kind()) {
+		pod, ok := resource.(model.Pod)
+		if !ok {
+			continue
+		}
+		for _, claimName := range pod.ClaimNames() {
+			if !contains(claimNames, claimName) {
+				logger.Debugf("Missing PersistentVolumeClaim %s for Pod %s", claimName, resource.Name())
+				namespaceModel.AddResource(model.NewDanglingReference(source.PersistentVolumeClaimKind.Kind, namespaceModel.Name(), claimName))
+			}
+		}
+	}
+}
@@ -3,16 +3,119 @@ package config
 import (
 	"io/ioutil"
 	"log"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
 
 type ExporterConfig struct {
-	Namespaces     []string `yaml:",flow"`
-	FormatterClass string
-	LogLevel       string
-	LogFile        string
-	KNative        bool
+	Namespaces        []string `yaml:",flow"`
+	NamespaceSelector NamespaceSelector
+	FormatterClass    string
+	LogLevel          string
+	LogFile           string
+	KNative           bool
+	Manifests         string
+	Parallelism       int
+	Strict            bool
+	DrawWarnings      bool
+	// Connect the Deployments and DeploymentConfigs directly to their Pods, hiding the ReplicaSets and ReplicationControllers
+	CollapseReplicaSets bool
+	// One of pods, workloads, applications
+	DetailLevel string
+	Events      Events
+	Metrics     Metrics
+	// Additional kinds collected with the dynamic client
+	CustomResources []CustomResource
+}
+
+// CustomResource defines an additional kind to collect and how it is connected to the other resources
+type CustomResource struct {
+	APIVersion string
+	Kind       string
+	Icon       string
+	Relations  []Relation
+}
+
+// Relation connects a CustomResource to the resources of another kind, either by name using the value of a field
+// or by matching the labels with a selector. Fields and selectors are JSONPath expressions like .spec.serviceName
+type Relation struct {
+	Name     string
+	Kind     string
+	Field    string
+	Selector string
+}
+
+// Events configures the collection of the Events attached to the resources
+type Events struct {
+	Enabled bool
+	// Collect only the Warning events
+	WarningsOnly bool
+	// Collect only the events seen within this duration, like 1h, or all of them when empty
+	Since string
+}
+
+// SinceDuration returns the maximum age of the collected events, 0 for no limit
+func (events Events) SinceDuration() time.Duration {
+	duration, err := time.ParseDuration(events.Since)
+	if err != nil {
+		return 0
+	}
+	return duration
+}
+
+// Metrics configures the collection of the CPU and memory usage of the Pods from the metrics.k8s.io API
+type Metrics struct {
+	Enabled bool
+	// Color the resources by the utilization of their CPU and memory requests, instead of their status
+	ColorByUtilization bool
+}
+
+// NamespaceSelector selects the namespaces to explore, in addition to the configured Namespaces
+type NamespaceSelector struct {
+	// Select all the projects visible to the current user, unless excluded
+	AllProjects bool
+	// Label selector of the namespaces, like team=payments
+	Labels string
+	// Name patterns of the namespaces to include and exclude: glob patterns like feature-*
+	// or regular expressions enclosed in slashes like /^feature-[0-9]+$/
+	Include []string `yaml:",flow"`
+	Exclude []string `yaml:",flow"`
+}
+
+// IsEnabled returns true if the namespaces must be discovered from the cluster, or excluded from the configured ones
+func (selector NamespaceSelector) IsEnabled() bool {
+	return selector.AllProjects || selector.Labels != "" || len(selector.Include) > 0 || len(selector.Exclude) > 0
+}
+
+// IsExcludeOnly returns true if the selector only excludes namespaces
+func (selector NamespaceSelector) IsExcludeOnly() bool {
+	return !selector.AllProjects && selector.Labels == "" && len(selector.Include) == 0 && len(selector.Exclude) > 0
+}
+
+const DefaultParallelism = 4
+
+// ParallelismOrDefault returns the maximum number of namespaces and requests processed concurrently
+func (config ExporterConfig) ParallelismOrDefault() int {
+	if config.Parallelism > 0 {
+		return config.Parallelism
+	}
+	return DefaultParallelism
+}
+
+const (
+	PodsDetailLevel         = "pods"
+	WorkloadsDetailLevel    = "workloads"
+	ApplicationsDetailLevel = "applications"
+)
+
+// DetailLevelOrDefault returns the level of detail of the diagram: the Pods, their workloads only, or the
+// applications grouping the workloads
+func (config ExporterConfig) DetailLevelOrDefault() string {
+	if config.DetailLevel != "" {
+		return config.DetailLevel
+	}
+	return PodsDetailLevel
 }
 
 func ReadConfig() *ExporterConfig {
@@ -27,5 +130,15 @@ func ReadConfig() *ExporterConfig {
 	if err != nil {
 		log.Fatal(err)
 	}
+	switch exporterConfig.DetailLevelOrDefault() {
+	case PodsDetailLevel, WorkloadsDetailLevel, ApplicationsDetailLevel:
+	default:
+		log.Fatalf("Unknown detail level %s", exporterConfig.DetailLevel)
+	}
+	if exporterConfig.Events.Since != "" {
+		if _, err := time.ParseDuration(exporterConfig.Events.Since); err != nil {
+			log.Fatalf("Invalid events duration %s: %v", exporterConfig.Events.Since, err)
+		}
+	}
 	return &exporterConfig
 }
@@ -9,7 +9,9 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
-var logger *zap.SugaredLogger
+// Discards the logs until InitLogger is called, so that the builder can be embedded with its own Source
+// without initializing the logger of the exporter
+var logger = zap.NewNop().Sugar()
 
 func InitLogger(exporterConfig config.ExporterConfig) {
 
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PartOfLabel groups the workloads of an application, as in the OpenShift console
+const PartOfLabel = "app.kubernetes.io/part-of"
+
+// Application aggregates the workloads sharing the same PartOfLabel
+type Application struct {
+	PartOf string
+	Ready  int
+	Total  int
+	// Usage is the sum of the CPU and memory usage of the workloads, nil when the metrics were not collected
+	Usage *Utilization
+}
+
+// Add sums the Pods and usage of the given workload
+func (a *Application) Add(workload Workload) {
+	a.Ready += workload.Ready
+	a.Total += workload.Total
+	if usage, ok := workload.Utilization(); ok {
+		a.Usage = addUtilization(a.Usage, usage)
+	}
+}
+
+func (a Application) Kind() string {
+	return "Application"
+}
+func (a Application) Id() string {
+	return fmt.Sprintf("app %s", a.PartOf)
+}
+func (a Application) Name() string {
+	return a.PartOf
+}
+func (a Application) Label() string {
+	if a.Usage != nil {
+		return fmt.Sprintf("%s (%d/%d, %s)", a.PartOf, a.Ready, a.Total, a.Usage)
+	}
+	return fmt.Sprintf("%s (%d/%d)", a.PartOf, a.Ready, a.Total)
+}
+func (a Application) Utilization() (Utilization, bool) {
+	if a.Usage == nil {
+		return Utilization{}, false
+	}
+	return *a.Usage, true
+}
+func (a Application) Icon() string {
+	return "images/generic.png"
+}
+
+// StatusColor returns the status of the Pods of all the workloads
+func (a Application) StatusColor() (string, bool) {
+	return podsStatusColor(a.Ready, a.Total)
+}
+func (a Application) OwnerReferences() []metav1.OwnerReference {
+	return []metav1.OwnerReference{}
+}
+func (a Application) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (a Application) ConnectedKinds() []string {
+	return []string{}
+}
+func (a Application) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"fmt"
+
+	buildv1 "github.com/openshift/api/build/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Build struct {
+	Delegate buildv1.Build
+}
+
+func (b Build) Kind() string {
+	return "Build"
+}
+func (b Build) Id() string {
+	return fmt.Sprintf("build %s", b.Delegate.Name)
+}
+func (b Build) Name() string {
+	return b.Delegate.Name
+}
+func (b Build) Label() string {
+	return fmt.Sprintf("%s (%s)", b.Delegate.Name, b.Delegate.Status.Phase)
+}
+func (b Build) Labels() map[string]string {
+	return b.Delegate.Labels
+}
+func (b Build) Icon() string {
+	return "images/generic.png"
+}
+func (b Build) StatusColor() (string, bool) {
+	switch b.Delegate.Status.Phase {
+	case buildv1.BuildPhaseNew, buildv1.BuildPhasePending:
+		return PendingColor, true
+	case buildv1.BuildPhaseRunning:
+		return RunningColor, true
+	case buildv1.BuildPhaseComplete:
+		return CompletedColor, true
+	case buildv1.BuildPhaseFailed, buildv1.BuildPhaseError:
+		return FailedColor, true
+	}
+	return "", false
+}
+func (b Build) OwnerReferences() []metav1.OwnerReference {
+	return b.Delegate.OwnerReferences
+}
+func (b Build) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, buildv1.GroupVersion.WithKind(b.Kind()).GroupKind(), &b.Delegate)
+}
+func (b Build) ConnectedKinds() []string {
+	return []string{"ImageStreamTag"}
+}
+func (b Build) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	connected := make([]Resource, 0)
+	if b.Delegate.Spec.Output.To == nil {
+		return connected, ""
+	}
+	for _, resource := range resources {
+		if tag, ok := resource.(ImageStreamTag); ok && tag.IsReferencedBy(*b.Delegate.Spec.Output.To, b.Delegate.Namespace) {
+			connected = append(connected, tag)
+		}
+	}
+	return connected, "output"
+}
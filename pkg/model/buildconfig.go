@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+
+	buildv1 "github.com/openshift/api/build/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type BuildConfig struct {
+	Delegate buildv1.BuildConfig
+}
+
+func (b BuildConfig) Kind() string {
+	return "BuildConfig"
+}
+func (b BuildConfig) Id() string {
+	return fmt.Sprintf("bc %s", b.Delegate.Name)
+}
+func (b BuildConfig) Name() string {
+	return b.Delegate.Name
+}
+func (b BuildConfig) Label() string {
+	return b.Delegate.Name
+}
+func (b BuildConfig) Labels() map[string]string {
+	return b.Delegate.Labels
+}
+func (b BuildConfig) Icon() string {
+	return "images/generic.png"
+}
+func (b BuildConfig) StatusColor() (string, bool) {
+	return "", false
+}
+func (b BuildConfig) OwnerReferences() []metav1.OwnerReference {
+	return b.Delegate.OwnerReferences
+}
+func (b BuildConfig) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, buildv1.GroupVersion.WithKind(b.Kind()).GroupKind(), &b.Delegate)
+}
+func (b BuildConfig) ConnectedKinds() []string {
+	return []string{"ImageStreamTag"}
+}
+
+// ConnectedResources returns the output ImageStreamTag of a BuildConfig that was never built, otherwise the
+// ImageStreamTag is connected to its Builds
+func (b BuildConfig) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	connected := make([]Resource, 0)
+	if b.Delegate.Status.LastVersion > 0 || b.Delegate.Spec.Output.To == nil {
+		return connected, ""
+	}
+	for _, resource := range resources {
+		if tag, ok := resource.(ImageStreamTag); ok && tag.IsReferencedBy(*b.Delegate.Spec.Output.To, b.Delegate.Namespace) {
+			connected = append(connected, tag)
+		}
+	}
+	return connected, "output"
+}
@@ -1,11 +1,13 @@
 package model
 
 import (
+	"fmt"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ClusterServiceVersion is the placeholder of an operator owning some resources, when its CSV cannot be fetched
 type ClusterServiceVersion struct {
 	Delegate metav1.OwnerReference
 }
@@ -20,7 +22,7 @@ func (csv ClusterServiceVersion) Name() string {
 	return csv.Delegate.Name
 }
 func (csv ClusterServiceVersion) Label() string {
-	return csv.Delegate.Name
+	return fmt.Sprintf("%s (unresolved)", csv.Delegate.Name)
 }
 func (csv ClusterServiceVersion) Icon() string {
 	return "images/operator.png"
@@ -29,6 +31,9 @@ func (csv ClusterServiceVersion) OwnerReferences() []metav1.OwnerReference {
 	return []metav1.OwnerReference{}
 }
 func (csv ClusterServiceVersion) IsOwnerOf(owner metav1.OwnerReference) bool {
+	if owner.UID != "" && csv.Delegate.UID != "" {
+		return owner.UID == csv.Delegate.UID
+	}
 	return strings.Compare(owner.Kind, csv.Kind()) == 0 && strings.Compare(owner.Name, csv.Name()) == 0
 }
 func (csv ClusterServiceVersion) ConnectedKinds() []string {
@@ -39,5 +44,5 @@ func (csv ClusterServiceVersion) ConnectedResources(kind string, resources []Res
 }
 
 func (csv ClusterServiceVersion) StatusColor() (string, bool) {
-	return "", false
+	return MissingColor, true
 }
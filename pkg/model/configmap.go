@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ConfigMap struct {
+	Delegate v1.ConfigMap
+}
+
+func (c ConfigMap) Kind() string {
+	return "ConfigMap"
+}
+func (c ConfigMap) Id() string {
+	return fmt.Sprintf("cm %s", c.Delegate.Name)
+}
+func (c ConfigMap) Name() string {
+	return c.Delegate.Name
+}
+func (c ConfigMap) Label() string {
+	return c.Delegate.Name
+}
+func (c ConfigMap) Labels() map[string]string {
+	return c.Delegate.Labels
+}
+func (c ConfigMap) Icon() string {
+	return "images/generic.png"
+}
+func (c ConfigMap) StatusColor() (string, bool) {
+	return "", false
+}
+func (c ConfigMap) OwnerReferences() []metav1.OwnerReference {
+	return c.Delegate.OwnerReferences
+}
+func (c ConfigMap) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (c ConfigMap) ConnectedKinds() []string {
+	return []string{}
+}
+func (c ConfigMap) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ConfigReference is a reference from a pod template to a ConfigMap or a Secret
+type ConfigReference struct {
+	// Kind of the referenced object, either ConfigMap or Secret
+	Kind string
+	Name string
+	// How the object is referenced: envFrom, valueFrom, volume or imagePullSecrets
+	Via string
+	// Optional references do not require the object to exist
+	Optional bool
+}
+
+// ConfigReferrer is implemented by the resources referencing ConfigMaps and Secrets
+type ConfigReferrer interface {
+	ConfigReferences() []ConfigReference
+}
+
+// NamedConnector is implemented by the resources whose connections to the same kind have different names
+type NamedConnector interface {
+	ConnectionName(to Resource) string
+}
+
+// ConfigReferencesOf returns the ConfigMaps and Secrets referenced by the given pod spec
+func ConfigReferencesOf(spec v1.PodSpec) []ConfigReference {
+	references := make([]ConfigReference, 0)
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				references = append(references, ConfigReference{Kind: "ConfigMap", Name: envFrom.ConfigMapRef.Name,
+					Via: "envFrom", Optional: isOptional(envFrom.ConfigMapRef.Optional)})
+			}
+			if envFrom.SecretRef != nil {
+				references = append(references, ConfigReference{Kind: "Secret", Name: envFrom.SecretRef.Name,
+					Via: "envFrom", Optional: isOptional(envFrom.SecretRef.Optional)})
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				references = append(references, ConfigReference{Kind: "ConfigMap", Name: env.ValueFrom.ConfigMapKeyRef.Name,
+					Via: "valueFrom", Optional: isOptional(env.ValueFrom.ConfigMapKeyRef.Optional)})
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				references = append(references, ConfigReference{Kind: "Secret", Name: env.ValueFrom.SecretKeyRef.Name,
+					Via: "valueFrom", Optional: isOptional(env.ValueFrom.SecretKeyRef.Optional)})
+			}
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			references = append(references, ConfigReference{Kind: "ConfigMap", Name: volume.ConfigMap.Name,
+				Via: "volume", Optional: isOptional(volume.ConfigMap.Optional)})
+		}
+		if volume.Secret != nil {
+			references = append(references, ConfigReference{Kind: "Secret", Name: volume.Secret.SecretName,
+				Via: "volume", Optional: isOptional(volume.Secret.Optional)})
+		}
+		if volume.Projected != nil && !isServiceAccountTokenVolume(*volume.Projected) {
+			for _, projection := range volume.Projected.Sources {
+				if projection.ConfigMap != nil {
+					references = append(references, ConfigReference{Kind: "ConfigMap", Name: projection.ConfigMap.Name,
+						Via: "volume", Optional: isOptional(projection.ConfigMap.Optional)})
+				}
+				if projection.Secret != nil {
+					references = append(references, ConfigReference{Kind: "Secret", Name: projection.Secret.Name,
+						Via: "volume", Optional: isOptional(projection.Secret.Optional)})
+				}
+			}
+		}
+	}
+	for _, imagePullSecret := range spec.ImagePullSecrets {
+		references = append(references, ConfigReference{Kind: "Secret", Name: imagePullSecret.Name, Via: "imagePullSecrets"})
+	}
+	return references
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// isServiceAccountTokenVolume returns true for the volumes injected in every pod with the token of the
+// ServiceAccount and the cluster CA, like kube-api-access-*
+func isServiceAccountTokenVolume(projected v1.ProjectedVolumeSource) bool {
+	for _, projection := range projected.Sources {
+		if projection.ServiceAccountToken != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isConfigReferenceTo(reference ConfigReference, resource Resource) bool {
+	if dangling, ok := resource.(DanglingReference); ok {
+		return dangling.IsReferenceTo(reference.Kind, reference.Name)
+	}
+	return strings.Compare(resource.Kind(), reference.Kind) == 0 && strings.Compare(resource.Name(), reference.Name) == 0
+}
+
+// connectedConfigResources returns the resources matching the given references
+func connectedConfigResources(references []ConfigReference, resources []Resource) []Resource {
+	connected := make([]Resource, 0)
+	for _, resource := range resources {
+		for _, reference := range references {
+			if isConfigReferenceTo(reference, resource) {
+				connected = append(connected, resource)
+				break
+			}
+		}
+	}
+	return connected
+}
+
+// configConnectionName lists the ways the given resource is referenced, like "envFrom, volume"
+func configConnectionName(references []ConfigReference, to Resource) string {
+	vias := make([]string, 0)
+	for _, reference := range references {
+		if isConfigReferenceTo(reference, to) && !containsString(vias, reference.Via) {
+			vias = append(vias, reference.Via)
+		}
+	}
+	return strings.Join(vias, ", ")
+}
@@ -1,7 +1,21 @@
 package model
 
+import "strings"
+
 type Connection struct {
 	From Resource
 	To   Resource
 	Name string
 }
+
+// The names of the connections to the alternate Destinations of the events, drawn distinctly
+const (
+	ReplyConnection      = "reply"
+	DeadLetterConnection = "dead letter"
+)
+
+// IsAlternatePath returns true if the connection leads to the reply or the dead letter sink of the events, like
+// "reply" or "seq reply" for the reply of a Sequence
+func (c Connection) IsAlternatePath() bool {
+	return c.Name == DeadLetterConnection || c.Name == ReplyConnection || strings.HasSuffix(c.Name, " "+ReplyConnection)
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CronJob struct {
+	Delegate v1.CronJob
+}
+
+func (c CronJob) Kind() string {
+	return "CronJob"
+}
+func (c CronJob) Id() string {
+	return fmt.Sprintf("cronjob %s", c.Delegate.Name)
+}
+func (c CronJob) Name() string {
+	return c.Delegate.Name
+}
+func (c CronJob) Label() string {
+	if c.Delegate.Spec.Suspend != nil && *c.Delegate.Spec.Suspend {
+		return fmt.Sprintf("%s (suspended)", c.Delegate.Name)
+	}
+	if c.Delegate.Status.LastScheduleTime == nil {
+		return fmt.Sprintf("%s (never scheduled)", c.Delegate.Name)
+	}
+	return fmt.Sprintf("%s (last scheduled %s)", c.Delegate.Name,
+		c.Delegate.Status.LastScheduleTime.UTC().Format("2006-01-02 15:04 MST"))
+}
+func (c CronJob) Labels() map[string]string {
+	return c.Delegate.Labels
+}
+func (c CronJob) Icon() string {
+	return "images/generic.png"
+}
+
+// StatusColor shows the CronJobs with running Jobs
+func (c CronJob) StatusColor() (string, bool) {
+	if len(c.Delegate.Status.Active) > 0 {
+		return RunningColor, true
+	}
+	return "", false
+}
+func (c CronJob) OwnerReferences() []metav1.OwnerReference {
+	return c.Delegate.OwnerReferences
+}
+func (c CronJob) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, v1.SchemeGroupVersion.WithKind(c.Kind()).GroupKind(), &c.Delegate)
+}
+func (c CronJob) ConnectedKinds() []string {
+	return []string{}
+}
+func (c CronJob) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
@@ -5,14 +5,17 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// CustomResource models an owner that could not be fetched, knowing only its kind and name
 type CustomResource struct {
 	Delegate metav1.OwnerReference
 }
 
 func (cr CustomResource) Kind() string {
-	return cr.Delegate.Kind
+	groupVersion, _ := schema.ParseGroupVersion(cr.Delegate.APIVersion)
+	return QualifiedKind(groupVersion.WithKind(cr.Delegate.Kind).GroupKind())
 }
 func (cr CustomResource) Id() string {
 	return fmt.Sprintf("%s %s", strings.ToLower(cr.Kind()), cr.Delegate.Name)
@@ -21,7 +24,7 @@ func (cr CustomResource) Name() string {
 	return cr.Delegate.Name
 }
 func (cr CustomResource) Label() string {
-	return cr.Delegate.Name
+	return fmt.Sprintf("%s (unresolved)", cr.Delegate.Name)
 }
 func (cr CustomResource) Icon() string {
 	return "images/crd.png"
@@ -30,7 +33,10 @@ func (cr CustomResource) OwnerReferences() []metav1.OwnerReference {
 	return []metav1.OwnerReference{}
 }
 func (cr CustomResource) IsOwnerOf(owner metav1.OwnerReference) bool {
-	return strings.Compare(owner.Kind, cr.Kind()) == 0 && strings.Compare(owner.Name, cr.Name()) == 0
+	if owner.UID != "" && cr.Delegate.UID != "" {
+		return owner.UID == cr.Delegate.UID
+	}
+	return strings.Compare(owner.Kind, cr.Delegate.Kind) == 0 && strings.Compare(owner.Name, cr.Name()) == 0
 }
 func (cr CustomResource) ConnectedKinds() []string {
 	return []string{""}
@@ -39,5 +45,5 @@ func (cr CustomResource) ConnectedResources(kind string, resources []Resource) (
 	return []Resource{}, ""
 }
 func (cr CustomResource) StatusColor() (string, bool) {
-	return "", false
+	return MissingColor, true
 }
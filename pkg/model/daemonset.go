@@ -0,0 +1,49 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DaemonSet struct {
+	Delegate v1.DaemonSet
+}
+
+func (d DaemonSet) Kind() string {
+	return "DaemonSet"
+}
+func (d DaemonSet) Id() string {
+	return fmt.Sprintf("ds %s", d.Delegate.Name)
+}
+func (d DaemonSet) Name() string {
+	return d.Delegate.Name
+}
+func (d DaemonSet) Label() string {
+	return fmt.Sprintf("%s (%d/%d)", d.Delegate.Name, d.Delegate.Status.NumberReady, d.Delegate.Status.DesiredNumberScheduled)
+}
+func (d DaemonSet) Labels() map[string]string {
+	return d.Delegate.Labels
+}
+func (d DaemonSet) Icon() string {
+	return "images/deployment.png"
+}
+
+// StatusColor compares the ready and updated daemon pods with the desired ones
+func (d DaemonSet) StatusColor() (string, bool) {
+	return rolloutStatusColor(d.Delegate.Status.ObservedGeneration, d.Delegate.Status.DesiredNumberScheduled,
+		d.Delegate.Status.NumberReady, d.Delegate.Status.UpdatedNumberScheduled, []Condition{})
+}
+func (d DaemonSet) OwnerReferences() []metav1.OwnerReference {
+	return d.Delegate.OwnerReferences
+}
+func (d DaemonSet) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, v1.SchemeGroupVersion.WithKind(d.Kind()).GroupKind(), &d.Delegate)
+}
+func (d DaemonSet) ConnectedKinds() []string {
+	return []string{}
+}
+func (d DaemonSet) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const DanglingReferenceKind = "DanglingReference"
+
+// DanglingReference models a resource that is referenced by another one but does not exist
+type DanglingReference struct {
+	Delegate v1.ObjectReference
+}
+
+func NewDanglingReference(kind string, namespace string, name string) DanglingReference {
+	return DanglingReference{Delegate: v1.ObjectReference{Kind: kind, Namespace: namespace, Name: name}}
+}
+
+// IsReferenceTo returns true if the missing resource has the given kind and name
+func (d DanglingReference) IsReferenceTo(kind string, name string) bool {
+	return strings.Compare(d.Delegate.Kind, kind) == 0 && strings.Compare(d.Delegate.Name, name) == 0
+}
+
+func (d DanglingReference) Kind() string {
+	return DanglingReferenceKind
+}
+func (d DanglingReference) Id() string {
+	return fmt.Sprintf("missing %s %s", strings.ToLower(d.Delegate.Kind), d.Delegate.Name)
+}
+func (d DanglingReference) Name() string {
+	return d.Delegate.Name
+}
+func (d DanglingReference) Label() string {
+	return fmt.Sprintf("missing %s %s", d.Delegate.Kind, d.Delegate.Name)
+}
+func (d DanglingReference) Icon() string {
+	return "images/generic.png"
+}
+func (d DanglingReference) StatusColor() (string, bool) {
+	return MissingColor, true
+}
+func (d DanglingReference) OwnerReferences() []metav1.OwnerReference {
+	return []metav1.OwnerReference{}
+}
+func (d DanglingReference) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (d DanglingReference) ConnectedKinds() []string {
+	return []string{}
+}
+func (d DanglingReference) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
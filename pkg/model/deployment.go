@@ -2,7 +2,6 @@ package model
 
 import (
 	"fmt"
-	"strings"
 
 	v1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,27 +23,35 @@ func (d Deployment) Name() string {
 func (d Deployment) Label() string {
 	return d.Delegate.Name
 }
+func (d Deployment) Labels() map[string]string {
+	return d.Delegate.Labels
+}
 func (d Deployment) Icon() string {
 	return "images/deployment.png"
 }
 func (d Deployment) StatusColor() (string, bool) {
-	return "", false
+	conditions := make([]Condition, 0)
+	for _, c := range d.Delegate.Status.Conditions {
+		conditions = append(conditions, Condition{Type: string(c.Type), Status: string(c.Status), Reason: c.Reason})
+	}
+	return rolloutStatusColor(d.Delegate.Status.ObservedGeneration, desiredReplicas(d.Delegate.Spec.Replicas),
+		d.Delegate.Status.AvailableReplicas, d.Delegate.Status.UpdatedReplicas, conditions)
 }
 func (d Deployment) OwnerReferences() []metav1.OwnerReference {
 	return d.Delegate.OwnerReferences
 }
 func (d Deployment) IsOwnerOf(owner metav1.OwnerReference) bool {
-	switch owner.Kind {
-	case "Deployment":
-		return strings.Compare(owner.Name, d.Name()) == 0
-	case "ReplicaSet":
-		return strings.HasPrefix(owner.Name, d.Name())
-	}
-	return false
+	return IsOwnedBy(owner, v1.SchemeGroupVersion.WithKind(d.Kind()).GroupKind(), &d.Delegate)
 }
 func (d Deployment) ConnectedKinds() []string {
-	return []string{}
+	return []string{"ConfigMap", "Secret", DanglingReferenceKind}
 }
 func (d Deployment) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
-	return []Resource{}, ""
+	return connectedConfigResources(d.ConfigReferences(), resources), ""
+}
+func (d Deployment) ConfigReferences() []ConfigReference {
+	return ConfigReferencesOf(d.Delegate.Spec.Template.Spec)
+}
+func (d Deployment) ConnectionName(to Resource) string {
+	return configConnectionName(d.ConfigReferences(), to)
 }
@@ -2,9 +2,9 @@ package model
 
 import (
 	"fmt"
-	"strings"
 
 	appsv1T "github.com/openshift/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,27 +24,49 @@ func (d DeploymentConfig) Name() string {
 func (d DeploymentConfig) Label() string {
 	return d.Delegate.Name
 }
+func (d DeploymentConfig) Labels() map[string]string {
+	return d.Delegate.Labels
+}
 func (d DeploymentConfig) Icon() string {
 	return "images/deployment.png"
 }
 func (d DeploymentConfig) StatusColor() (string, bool) {
-	return "", false
+	conditions := make([]Condition, 0)
+	for _, c := range d.Delegate.Status.Conditions {
+		conditions = append(conditions, Condition{Type: string(c.Type), Status: string(c.Status), Reason: c.Reason})
+	}
+	return rolloutStatusColor(d.Delegate.Status.ObservedGeneration, d.Delegate.Spec.Replicas,
+		d.Delegate.Status.AvailableReplicas, d.Delegate.Status.UpdatedReplicas, conditions)
 }
 func (d DeploymentConfig) OwnerReferences() []metav1.OwnerReference {
 	return d.Delegate.OwnerReferences
 }
 func (d DeploymentConfig) IsOwnerOf(owner metav1.OwnerReference) bool {
-	switch owner.Kind {
-	case "DeploymentConfig":
-		return strings.Compare(owner.Name, d.Name()) == 0
-	case "ReplicationController":
-		return strings.HasPrefix(owner.Name, d.Name())
-	}
-	return false
+	return IsOwnedBy(owner, appsv1T.GroupVersion.WithKind(d.Kind()).GroupKind(), &d.Delegate)
 }
 func (d DeploymentConfig) ConnectedKinds() []string {
-	return []string{}
+	return []string{"ConfigMap", "Secret", DanglingReferenceKind}
 }
 func (d DeploymentConfig) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
-	return []Resource{}, ""
+	return connectedConfigResources(d.ConfigReferences(), resources), ""
+}
+func (d DeploymentConfig) ConfigReferences() []ConfigReference {
+	if d.Delegate.Spec.Template == nil {
+		return []ConfigReference{}
+	}
+	return ConfigReferencesOf(d.Delegate.Spec.Template.Spec)
+}
+func (d DeploymentConfig) ConnectionName(to Resource) string {
+	return configConnectionName(d.ConfigReferences(), to)
+}
+
+// ImageTriggers returns the ImageStreamTags of the ImageChange triggers of the DeploymentConfig
+func (d DeploymentConfig) ImageTriggers() []corev1.ObjectReference {
+	references := make([]corev1.ObjectReference, 0)
+	for _, trigger := range d.Delegate.Spec.Triggers {
+		if trigger.Type == appsv1T.DeploymentTriggerOnImageChange && trigger.ImageChangeParams != nil {
+			references = append(references, trigger.ImageChangeParams.From)
+		}
+	}
+	return references
 }
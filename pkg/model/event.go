@@ -0,0 +1,84 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MaxEventMessages is the number of latest Event messages drawn for each resource
+const MaxEventMessages = 3
+
+// Event is a Kubernetes Event about a resource, like a failure to pull an image
+type Event struct {
+	Type     string
+	Reason   string
+	Message  string
+	Count    int32
+	LastSeen time.Time
+}
+
+func NewEvent(event v1.Event) Event {
+	lastSeen := event.LastTimestamp.Time
+	if lastSeen.IsZero() && event.Series != nil {
+		lastSeen = event.Series.LastObservedTime.Time
+	}
+	if lastSeen.IsZero() {
+		lastSeen = event.EventTime.Time
+	}
+	if lastSeen.IsZero() {
+		lastSeen = event.CreationTimestamp.Time
+	}
+	return Event{Type: event.Type, Reason: event.Reason, Message: event.Message, Count: event.Count, LastSeen: lastSeen}
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Message)
+}
+
+// Events holds the Events of the resources of a namespace, the latest first
+type Events map[string][]Event
+
+// Of returns the Events of the given resource, the latest first
+func (events Events) Of(resource Resource) []Event {
+	return events[eventsKey(resource)]
+}
+
+func (events Events) add(resource Resource, newEvents ...Event) {
+	key := eventsKey(resource)
+	events[key] = append(events[key], newEvents...)
+	sort.SliceStable(events[key], func(i, j int) bool {
+		return events[key][i].LastSeen.After(events[key][j].LastSeen)
+	})
+}
+
+func eventsKey(resource Resource) string {
+	return fmt.Sprintf("%s/%s", resource.Kind(), resource.Id())
+}
+
+// UIDOf returns the UID of the Kubernetes object delegated by the given resource, or an empty UID
+func UIDOf(resource Resource) types.UID {
+	if workload, ok := resource.(Workload); ok {
+		return UIDOf(workload.Resource)
+	}
+	value := reflect.ValueOf(resource)
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+	delegate := value.FieldByName("Delegate")
+	if !delegate.IsValid() {
+		return ""
+	}
+	// The object methods have pointer receivers
+	pointer := reflect.New(delegate.Type())
+	pointer.Elem().Set(delegate)
+	if object, ok := pointer.Interface().(metav1.Object); ok {
+		return object.GetUID()
+	}
+	return ""
+}
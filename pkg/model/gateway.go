@@ -0,0 +1,88 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Gateway models a Gateway API Gateway, collected with the dynamic client
+type Gateway struct {
+	Delegate unstructured.Unstructured
+}
+
+func (g Gateway) Kind() string {
+	return "Gateway"
+}
+func (g Gateway) Id() string {
+	// Qualified with the namespace, as the Gateways are connected to the cluster-scoped GatewayClasses
+	return fmt.Sprintf("gateway %s/%s", g.Delegate.GetNamespace(), g.Delegate.GetName())
+}
+func (g Gateway) Name() string {
+	return g.Delegate.GetName()
+}
+func (g Gateway) Label() string {
+	hostnames := make([]string, 0)
+	listeners, _, _ := unstructured.NestedSlice(g.Delegate.Object, "spec", "listeners")
+	for _, listener := range listeners {
+		if fields, ok := listener.(map[string]interface{}); ok {
+			if hostname, ok := fields["hostname"].(string); ok && !containsString(hostnames, hostname) {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+	}
+	if len(hostnames) == 0 {
+		return g.Delegate.GetName()
+	}
+	return fmt.Sprintf("%s (%s)", g.Delegate.GetName(), strings.Join(hostnames, ", "))
+}
+func (g Gateway) Labels() map[string]string {
+	return g.Delegate.GetLabels()
+}
+func (g Gateway) Icon() string {
+	return "images/ingress.png"
+}
+
+// StatusColor shows whether the Gateway was programmed in the underlying infrastructure
+func (g Gateway) StatusColor() (string, bool) {
+	conditions, _, _ := unstructured.NestedSlice(g.Delegate.Object, "status", "conditions")
+	for _, value := range conditions {
+		condition, ok := value.(map[string]interface{})
+		if !ok || condition["type"] != "Programmed" {
+			continue
+		}
+		switch condition["status"] {
+		case "True":
+			return RunningColor, true
+		case "False":
+			return FailedColor, true
+		}
+	}
+	return "", false
+}
+func (g Gateway) OwnerReferences() []metav1.OwnerReference {
+	return g.Delegate.GetOwnerReferences()
+}
+func (g Gateway) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, g.Delegate.GroupVersionKind().GroupKind(), &g.Delegate)
+}
+func (g Gateway) ConnectedKinds() []string {
+	return []string{"HTTPRoute"}
+}
+func (g Gateway) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	connected := make([]Resource, 0)
+	for _, resource := range resources {
+		if route, ok := resource.(HTTPRoute); ok && route.IsAttachedTo(g.Name()) {
+			connected = append(connected, route)
+		}
+	}
+	return connected, "routes"
+}
+
+// ClassName returns the name of the GatewayClass
+func (g Gateway) ClassName() string {
+	className, _, _ := unstructured.NestedString(g.Delegate.Object, "spec", "gatewayClassName")
+	return className
+}
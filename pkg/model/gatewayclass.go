@@ -0,0 +1,47 @@
+package model
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// GatewayClass models a Gateway API GatewayClass, collected with the dynamic client
+type GatewayClass struct {
+	Delegate unstructured.Unstructured
+}
+
+func (g GatewayClass) Kind() string {
+	return "GatewayClass"
+}
+func (g GatewayClass) Id() string {
+	return fmt.Sprintf("gatewayclass %s", g.Delegate.GetName())
+}
+func (g GatewayClass) Name() string {
+	return g.Delegate.GetName()
+}
+func (g GatewayClass) Label() string {
+	return g.Delegate.GetName()
+}
+func (g GatewayClass) Labels() map[string]string {
+	return g.Delegate.GetLabels()
+}
+func (g GatewayClass) Icon() string {
+	return "images/generic.png"
+}
+func (g GatewayClass) StatusColor() (string, bool) {
+	return "", false
+}
+func (g GatewayClass) OwnerReferences() []metav1.OwnerReference {
+	return g.Delegate.GetOwnerReferences()
+}
+func (g GatewayClass) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (g GatewayClass) ConnectedKinds() []string {
+	return []string{}
+}
+func (g GatewayClass) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// HTTPRoute models a Gateway API HTTPRoute, collected with the dynamic client
+type HTTPRoute struct {
+	Delegate unstructured.Unstructured
+}
+
+func (h HTTPRoute) Kind() string {
+	return "HTTPRoute"
+}
+func (h HTTPRoute) Id() string {
+	return fmt.Sprintf("httproute %s", h.Delegate.GetName())
+}
+func (h HTTPRoute) Name() string {
+	return h.Delegate.GetName()
+}
+func (h HTTPRoute) Label() string {
+	hostnames, _, _ := unstructured.NestedStringSlice(h.Delegate.Object, "spec", "hostnames")
+	if len(hostnames) == 0 {
+		return h.Delegate.GetName()
+	}
+	return fmt.Sprintf("%s (%s)", h.Delegate.GetName(), strings.Join(hostnames, ", "))
+}
+func (h HTTPRoute) Labels() map[string]string {
+	return h.Delegate.GetLabels()
+}
+func (h HTTPRoute) Icon() string {
+	return "images/ingress.png"
+}
+func (h HTTPRoute) StatusColor() (string, bool) {
+	return "", false
+}
+func (h HTTPRoute) OwnerReferences() []metav1.OwnerReference {
+	return h.Delegate.GetOwnerReferences()
+}
+func (h HTTPRoute) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (h HTTPRoute) ConnectedKinds() []string {
+	return []string{"Service"}
+}
+func (h HTTPRoute) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	connected := make([]Resource, 0)
+	for _, resource := range resources {
+		if len(h.pathsTo(resource.Name())) > 0 {
+			connected = append(connected, resource)
+		}
+	}
+	return connected, "exposed"
+}
+
+// ConnectionName lists the paths of the rules routed to the given Service, like /api
+func (h HTTPRoute) ConnectionName(to Resource) string {
+	return strings.Join(h.pathsTo(to.Name()), ", ")
+}
+
+// IsAttachedTo returns true if the given Gateway of the same namespace is one of the parents of the route
+func (h HTTPRoute) IsAttachedTo(gateway string) bool {
+	parentRefs, _, _ := unstructured.NestedSlice(h.Delegate.Object, "spec", "parentRefs")
+	for _, value := range parentRefs {
+		parentRef, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if kind, ok := parentRef["kind"].(string); ok && kind != "Gateway" {
+			continue
+		}
+		if namespace, ok := parentRef["namespace"].(string); ok && namespace != h.Delegate.GetNamespace() {
+			continue
+		}
+		if parentRef["name"] == gateway {
+			return true
+		}
+	}
+	return false
+}
+
+// pathsTo returns the path matches of the rules with a backend pointing to the given Service
+func (h HTTPRoute) pathsTo(serviceName string) []string {
+	paths := make([]string, 0)
+	rules, _, _ := unstructured.NestedSlice(h.Delegate.Object, "spec", "rules")
+	for _, value := range rules {
+		rule, ok := value.(map[string]interface{})
+		if !ok || !hasServiceBackend(rule, serviceName) {
+			continue
+		}
+		matches, _, _ := unstructured.NestedSlice(rule, "matches")
+		rulePaths := make([]string, 0)
+		for _, match := range matches {
+			if match, ok := match.(map[string]interface{}); ok {
+				if path, found, _ := unstructured.NestedString(match, "path", "value"); found {
+					rulePaths = append(rulePaths, path)
+				}
+			}
+		}
+		if len(rulePaths) == 0 {
+			rulePaths = append(rulePaths, "/")
+		}
+		for _, path := range rulePaths {
+			if !containsString(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+func hasServiceBackend(rule map[string]interface{}, serviceName string) bool {
+	backendRefs, _, _ := unstructured.NestedSlice(rule, "backendRefs")
+	for _, value := range backendRefs {
+		backendRef, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if kind, ok := backendRef["kind"].(string); ok && kind != "Service" {
+			continue
+		}
+		if group, ok := backendRef["group"].(string); ok && group != "" {
+			continue
+		}
+		if backendRef["name"] == serviceName {
+			return true
+		}
+	}
+	return false
+}
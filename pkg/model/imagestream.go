@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	imagev1 "github.com/openshift/api/image/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ImageStream struct {
+	Delegate imagev1.ImageStream
+}
+
+func (i ImageStream) Kind() string {
+	return "ImageStream"
+}
+func (i ImageStream) Id() string {
+	return fmt.Sprintf("is %s", i.Delegate.Name)
+}
+func (i ImageStream) Name() string {
+	return i.Delegate.Name
+}
+func (i ImageStream) Label() string {
+	return i.Delegate.Name
+}
+func (i ImageStream) Labels() map[string]string {
+	return i.Delegate.Labels
+}
+func (i ImageStream) Icon() string {
+	return "images/generic.png"
+}
+func (i ImageStream) StatusColor() (string, bool) {
+	return "", false
+}
+func (i ImageStream) OwnerReferences() []metav1.OwnerReference {
+	return i.Delegate.OwnerReferences
+}
+func (i ImageStream) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, imagev1.GroupVersion.WithKind(i.Kind()).GroupKind(), &i.Delegate)
+}
+func (i ImageStream) ConnectedKinds() []string {
+	return []string{"ImageStreamTag"}
+}
+func (i ImageStream) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	connected := make([]Resource, 0)
+	for _, resource := range resources {
+		if tag, ok := resource.(ImageStreamTag); ok && tag.ImageStream == i.Delegate.Name {
+			connected = append(connected, tag)
+		}
+	}
+	return connected, ""
+}
+
+// Tags returns the tags of the ImageStream, either defined in its spec or pushed by a build, sorted by name
+func (i ImageStream) Tags() []ImageStreamTag {
+	tagsByName := make(map[string]ImageStreamTag)
+	for _, tag := range i.Delegate.Spec.Tags {
+		tagsByName[tag.Name] = ImageStreamTag{ImageStream: i.Delegate.Name, Tag: tag.Name}
+	}
+	for _, events := range i.Delegate.Status.Tags {
+		tag := ImageStreamTag{ImageStream: i.Delegate.Name, Tag: events.Tag}
+		if len(events.Items) > 0 {
+			tag.Image = events.Items[0].DockerImageReference
+		}
+		for _, condition := range events.Conditions {
+			if condition.Type == imagev1.ImportSuccess && condition.Status == "False" {
+				tag.ImportFailed = true
+			}
+		}
+		tagsByName[events.Tag] = tag
+	}
+	tags := make([]ImageStreamTag, 0, len(tagsByName))
+	for _, tag := range tagsByName {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Tag < tags[j].Tag
+	})
+	return tags
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImageTriggersAnnotation defines the ImageStreamTags whose changes update the image of a Kubernetes workload
+const ImageTriggersAnnotation = "image.openshift.io/triggers"
+
+// ImageStreamTag is a tag of an ImageStream, as found in the spec and status of the ImageStream
+type ImageStreamTag struct {
+	ImageStream string
+	Tag         string
+	// Image is the reference of the latest image of the tag, if any
+	Image        string
+	ImportFailed bool
+}
+
+func (i ImageStreamTag) Kind() string {
+	return "ImageStreamTag"
+}
+func (i ImageStreamTag) Id() string {
+	return fmt.Sprintf("istag %s", i.Name())
+}
+func (i ImageStreamTag) Name() string {
+	return fmt.Sprintf("%s:%s", i.ImageStream, i.Tag)
+}
+func (i ImageStreamTag) Label() string {
+	return i.Name()
+}
+func (i ImageStreamTag) Icon() string {
+	return "images/generic.png"
+}
+func (i ImageStreamTag) StatusColor() (string, bool) {
+	if i.ImportFailed {
+		return FailedColor, true
+	}
+	return "", false
+}
+func (i ImageStreamTag) OwnerReferences() []metav1.OwnerReference {
+	return []metav1.OwnerReference{}
+}
+func (i ImageStreamTag) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (i ImageStreamTag) ConnectedKinds() []string {
+	return []string{"DeploymentConfig", "Deployment"}
+}
+
+// ConnectedResources returns the workloads updated when the image of the tag changes
+func (i ImageStreamTag) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	connected := make([]Resource, 0)
+	for _, resource := range resources {
+		switch resource := resource.(type) {
+		case DeploymentConfig:
+			if i.isReferencedByAny(resource.ImageTriggers(), resource.Delegate.Namespace) {
+				connected = append(connected, resource)
+			}
+		case Deployment:
+			if i.isReferencedByAny(ImageTriggersOf(resource.Delegate.Annotations), resource.Delegate.Namespace) {
+				connected = append(connected, resource)
+			}
+		}
+	}
+	return connected, "image change"
+}
+
+// IsReferencedBy returns true if the given reference from a resource of the given namespace points to the tag.
+// The tag defaults to latest
+func (i ImageStreamTag) IsReferencedBy(reference v1.ObjectReference, namespace string) bool {
+	if reference.Kind != i.Kind() || (reference.Namespace != "" && reference.Namespace != namespace) {
+		return false
+	}
+	name := reference.Name
+	if !strings.Contains(name, ":") {
+		name = name + ":latest"
+	}
+	return strings.Compare(name, i.Name()) == 0
+}
+
+func (i ImageStreamTag) isReferencedByAny(references []v1.ObjectReference, namespace string) bool {
+	for _, reference := range references {
+		if i.IsReferencedBy(reference, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// ImageTriggersOf returns the ImageStreamTags of the ImageTriggersAnnotation in the given annotations
+func ImageTriggersOf(annotations map[string]string) []v1.ObjectReference {
+	value, ok := annotations[ImageTriggersAnnotation]
+	if !ok {
+		return []v1.ObjectReference{}
+	}
+	var triggers []struct {
+		From v1.ObjectReference `json:"from"`
+	}
+	if err := json.Unmarshal([]byte(value), &triggers); err != nil {
+		return []v1.ObjectReference{}
+	}
+	references := make([]v1.ObjectReference, 0, len(triggers))
+	for _, trigger := range triggers {
+		references = append(references, trigger.From)
+	}
+	return references
+}
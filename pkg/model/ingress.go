@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Ingress struct {
+	Delegate v1.Ingress
+}
+
+func (i Ingress) Kind() string {
+	return "Ingress"
+}
+func (i Ingress) Id() string {
+	// Qualified with the namespace, as the Ingresses are connected to the cluster-scoped IngressClasses
+	return fmt.Sprintf("ingress %s/%s", i.Delegate.Namespace, i.Delegate.Name)
+}
+func (i Ingress) Name() string {
+	return i.Delegate.Name
+}
+func (i Ingress) Label() string {
+	hosts := make([]string, 0)
+	for _, rule := range i.Delegate.Spec.Rules {
+		if rule.Host != "" && !containsString(hosts, rule.Host) {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	if len(hosts) == 0 {
+		return i.Delegate.Name
+	}
+	return fmt.Sprintf("%s (%s)", i.Delegate.Name, strings.Join(hosts, ", "))
+}
+func (i Ingress) Labels() map[string]string {
+	return i.Delegate.Labels
+}
+func (i Ingress) Icon() string {
+	return "images/ingress.png"
+}
+func (i Ingress) StatusColor() (string, bool) {
+	return "", false
+}
+func (i Ingress) OwnerReferences() []metav1.OwnerReference {
+	return i.Delegate.OwnerReferences
+}
+func (i Ingress) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (i Ingress) ConnectedKinds() []string {
+	return []string{"Service"}
+}
+func (i Ingress) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	connected := make([]Resource, 0)
+	for _, resource := range resources {
+		if len(i.pathsTo(resource.Name())) > 0 {
+			connected = append(connected, resource)
+		}
+	}
+	return connected, "exposed"
+}
+
+// ConnectionName lists the paths routed to the given Service, like example.com/api
+func (i Ingress) ConnectionName(to Resource) string {
+	return strings.Join(i.pathsTo(to.Name()), ", ")
+}
+
+// ClassName returns the name of the IngressClass, from the spec or the legacy annotation
+func (i Ingress) ClassName() string {
+	if i.Delegate.Spec.IngressClassName != nil {
+		return *i.Delegate.Spec.IngressClassName
+	}
+	return i.Delegate.Annotations["kubernetes.io/ingress.class"]
+}
+
+func (i Ingress) pathsTo(serviceName string) []string {
+	paths := make([]string, 0)
+	if backend := i.Delegate.Spec.DefaultBackend; backend != nil && backend.Service != nil &&
+		strings.Compare(backend.Service.Name, serviceName) == 0 {
+		paths = append(paths, "default")
+	}
+	for _, rule := range i.Delegate.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil && strings.Compare(path.Backend.Service.Name, serviceName) == 0 {
+				paths = append(paths, fmt.Sprintf("%s%s", rule.Host, path.Path))
+			}
+		}
+	}
+	return paths
+}
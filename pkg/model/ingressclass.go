@@ -0,0 +1,54 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type IngressClass struct {
+	Delegate v1.IngressClass
+}
+
+func (i IngressClass) Kind() string {
+	return "IngressClass"
+}
+func (i IngressClass) Id() string {
+	return fmt.Sprintf("ingressclass %s", i.Delegate.Name)
+}
+func (i IngressClass) Name() string {
+	return i.Delegate.Name
+}
+func (i IngressClass) Label() string {
+	if i.IsDefault() {
+		return fmt.Sprintf("%s (default)", i.Delegate.Name)
+	}
+	return i.Delegate.Name
+}
+func (i IngressClass) Labels() map[string]string {
+	return i.Delegate.Labels
+}
+func (i IngressClass) Icon() string {
+	return "images/generic.png"
+}
+func (i IngressClass) StatusColor() (string, bool) {
+	return "", false
+}
+func (i IngressClass) OwnerReferences() []metav1.OwnerReference {
+	return i.Delegate.OwnerReferences
+}
+func (i IngressClass) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (i IngressClass) ConnectedKinds() []string {
+	return []string{}
+}
+func (i IngressClass) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
+
+// IsDefault returns true for the class of the Ingresses that do not specify one
+func (i IngressClass) IsDefault() bool {
+	return i.Delegate.Annotations[v1.AnnotationIsDefaultIngressClass] == "true"
+}
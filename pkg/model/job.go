@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Job struct {
+	Delegate v1.Job
+}
+
+func (j Job) Kind() string {
+	return "Job"
+}
+func (j Job) Id() string {
+	return fmt.Sprintf("job %s", j.Delegate.Name)
+}
+func (j Job) Name() string {
+	return j.Delegate.Name
+}
+func (j Job) Label() string {
+	return j.Delegate.Name
+}
+func (j Job) Labels() map[string]string {
+	return j.Delegate.Labels
+}
+func (j Job) Icon() string {
+	return "images/generic.png"
+}
+func (j Job) StatusColor() (string, bool) {
+	for _, c := range j.Delegate.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case v1.JobComplete:
+			return CompletedColor, true
+		case v1.JobFailed:
+			return FailedColor, true
+		}
+	}
+	if j.Delegate.Status.Active > 0 {
+		return RunningColor, true
+	}
+	return "", false
+}
+func (j Job) OwnerReferences() []metav1.OwnerReference {
+	return j.Delegate.OwnerReferences
+}
+func (j Job) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, v1.SchemeGroupVersion.WithKind(j.Kind()).GroupKind(), &j.Delegate)
+}
+func (j Job) ConnectedKinds() []string {
+	return []string{}
+}
+func (j Job) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
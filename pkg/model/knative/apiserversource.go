@@ -0,0 +1,57 @@
+package knative
+
+import (
+	"fmt"
+	"strings"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+type ApiServerSource struct {
+	Delegate sourcesv1.ApiServerSource
+}
+
+func (s ApiServerSource) Kind() string {
+	return "knative.ApiServerSource"
+}
+func (s ApiServerSource) Id() string {
+	return fmt.Sprintf("apiserversource %s", s.Delegate.Name)
+}
+func (s ApiServerSource) Name() string {
+	return s.Delegate.Name
+}
+
+// Label adds the kinds of the watched resources, like "Pod, Event"
+func (s ApiServerSource) Label() string {
+	kinds := make([]string, 0)
+	for _, resource := range s.Delegate.Spec.Resources {
+		kinds = append(kinds, resource.Kind)
+	}
+	if len(kinds) == 0 {
+		return fmt.Sprintf("apiserversource %s", s.Delegate.Name)
+	}
+	return fmt.Sprintf("apiserversource %s (%s)", s.Delegate.Name, strings.Join(kinds, ", "))
+}
+func (s ApiServerSource) Icon() string {
+	return "images/generic.png"
+}
+func (s ApiServerSource) StatusColor() (string, bool) {
+	return statusColorOf(s.Delegate.Status.Status)
+}
+func (s ApiServerSource) OwnerReferences() []metav1.OwnerReference {
+	return s.Delegate.OwnerReferences
+}
+func (s ApiServerSource) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return model.IsOwnedBy(owner, sourcesv1.Kind("ApiServerSource"), &s.Delegate)
+}
+func (s ApiServerSource) ConnectedKinds() []string {
+	return []string{}
+}
+func (s ApiServerSource) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
+func (s ApiServerSource) Destinations() []NamedDestination {
+	return []NamedDestination{{Destination: &s.Delegate.Spec.Sink, Name: "sink"}}
+}
@@ -28,7 +28,7 @@ func (b Broker) Icon() string {
 	return "images/generic.png"
 }
 func (b Broker) StatusColor() (string, bool) {
-	return "", false
+	return statusColorOf(b.Delegate.Status.Status)
 }
 func (b Broker) OwnerReferences() []metav1.OwnerReference {
 	return b.Delegate.OwnerReferences
@@ -42,3 +42,8 @@ func (b Broker) ConnectedKinds() []string {
 func (b Broker) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
 	return nil, ""
 }
+
+// Destinations returns the dead letter sink of the Broker, if any
+func (b Broker) Destinations() []NamedDestination {
+	return deadLetterSinkOf(b.Delegate.Spec.Delivery)
+}
@@ -0,0 +1,50 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+)
+
+// Channel is the generic Channel, backed by a Channel of the implementation in its template, like an InMemoryChannel
+type Channel struct {
+	Delegate messagingv1.Channel
+}
+
+func (c Channel) Kind() string {
+	return "knative.Channel"
+}
+func (c Channel) Id() string {
+	return fmt.Sprintf("channel %s", c.Delegate.Name)
+}
+func (c Channel) Name() string {
+	return c.Delegate.Name
+}
+func (c Channel) Label() string {
+	return fmt.Sprintf("channel %s", c.Delegate.Name)
+}
+func (c Channel) Icon() string {
+	return "images/generic.png"
+}
+func (c Channel) StatusColor() (string, bool) {
+	return statusColorOf(c.Delegate.Status.Status)
+}
+func (c Channel) OwnerReferences() []metav1.OwnerReference {
+	return c.Delegate.OwnerReferences
+}
+func (c Channel) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return model.IsOwnedBy(owner, messagingv1.Kind("Channel"), &c.Delegate)
+}
+func (c Channel) ConnectedKinds() []string {
+	return []string{}
+}
+func (c Channel) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
+
+// Destinations returns the dead letter sink of the Channel, if any
+func (c Channel) Destinations() []NamedDestination {
+	return deadLetterSinkOf(c.Delegate.Spec.Delivery)
+}
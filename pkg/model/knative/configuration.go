@@ -0,0 +1,50 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+type Configuration struct {
+	Delegate servingv1.Configuration
+}
+
+func (c Configuration) Kind() string {
+	return "knative.Configuration"
+}
+func (c Configuration) Id() string {
+	return fmt.Sprintf("kconfig %s", c.Delegate.Name)
+}
+func (c Configuration) Name() string {
+	return c.Delegate.Name
+}
+func (c Configuration) Label() string {
+	return fmt.Sprintf("kconfig %s", c.Delegate.Name)
+}
+func (c Configuration) Icon() string {
+	return "images/generic.png"
+}
+func (c Configuration) StatusColor() (string, bool) {
+	return statusColorOf(c.Delegate.Status.Status)
+}
+func (c Configuration) OwnerReferences() []metav1.OwnerReference {
+	return c.Delegate.OwnerReferences
+}
+func (c Configuration) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return model.IsOwnedBy(owner, servingv1.Kind("Configuration"), &c.Delegate)
+}
+func (c Configuration) ConnectedKinds() []string {
+	return []string{}
+}
+func (c Configuration) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
+
+// IsLatest returns true if the given Revision is the latest created or the latest ready one of the Configuration
+func (c Configuration) IsLatest(revisionName string) bool {
+	return revisionName == c.Delegate.Status.LatestCreatedRevisionName ||
+		revisionName == c.Delegate.Status.LatestReadyRevisionName
+}
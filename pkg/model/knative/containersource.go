@@ -0,0 +1,47 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+type ContainerSource struct {
+	Delegate sourcesv1.ContainerSource
+}
+
+func (s ContainerSource) Kind() string {
+	return "knative.ContainerSource"
+}
+func (s ContainerSource) Id() string {
+	return fmt.Sprintf("containersource %s", s.Delegate.Name)
+}
+func (s ContainerSource) Name() string {
+	return s.Delegate.Name
+}
+func (s ContainerSource) Label() string {
+	return fmt.Sprintf("containersource %s", s.Delegate.Name)
+}
+func (s ContainerSource) Icon() string {
+	return "images/generic.png"
+}
+func (s ContainerSource) StatusColor() (string, bool) {
+	return statusColorOf(s.Delegate.Status.Status)
+}
+func (s ContainerSource) OwnerReferences() []metav1.OwnerReference {
+	return s.Delegate.OwnerReferences
+}
+func (s ContainerSource) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return model.IsOwnedBy(owner, sourcesv1.Kind("ContainerSource"), &s.Delegate)
+}
+func (s ContainerSource) ConnectedKinds() []string {
+	return []string{}
+}
+func (s ContainerSource) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
+func (s ContainerSource) Destinations() []NamedDestination {
+	return []NamedDestination{{Destination: &s.Delegate.Spec.Sink, Name: "sink"}}
+}
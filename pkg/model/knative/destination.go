@@ -0,0 +1,58 @@
+package knative
+
+import (
+	"strings"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// NamedDestination is a Destination of the events sent by a resource, with the name of the connection to it
+type NamedDestination struct {
+	Destination *duckv1.Destination
+	Name        string
+}
+
+// Addresser is implemented by the resources sending events to Destinations, that are resolved by the builder
+type Addresser interface {
+	Destinations() []NamedDestination
+}
+
+// Hop is a step of a flow, from a destination to the next one. A nil From is the flow itself
+type Hop struct {
+	From *duckv1.Destination
+	To   *duckv1.Destination
+	Name string
+}
+
+// Flow is implemented by the resources chaining their Destinations, like the Sequences
+type Flow interface {
+	Hops() []Hop
+}
+
+// KindOf returns the kind of the resource modelling the referenced object, prefixed with knative. for the Knative groups
+func KindOf(ref *duckv1.KReference) string {
+	group := ref.Group
+	if groupVersion, err := schema.ParseGroupVersion(ref.APIVersion); err == nil && group == "" {
+		group = groupVersion.Group
+	}
+	if strings.HasSuffix(group, "knative.dev") {
+		return "knative." + ref.Kind
+	}
+	return ref.Kind
+}
+
+// RefersTo returns true if the given reference points to the resource
+func RefersTo(ref *duckv1.KReference, resource model.Resource) bool {
+	return ref != nil && strings.Compare(KindOf(ref), resource.Kind()) == 0 && strings.Compare(ref.Name, resource.Name()) == 0
+}
+
+// deadLetterSinkOf returns the dead letter sink of the given delivery, if any, as a NamedDestination
+func deadLetterSinkOf(delivery *eventingduckv1.DeliverySpec) []NamedDestination {
+	if delivery == nil || delivery.DeadLetterSink == nil {
+		return []NamedDestination{}
+	}
+	return []NamedDestination{{Destination: delivery.DeadLetterSink, Name: model.DeadLetterConnection}}
+}
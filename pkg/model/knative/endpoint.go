@@ -0,0 +1,58 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// Endpoint is an external Destination: a URI, or a reference to a resource of another namespace or of a kind that
+// is not collected
+type Endpoint struct {
+	Delegate duckv1.Destination
+}
+
+func (e Endpoint) Kind() string {
+	return "knative.Endpoint"
+}
+func (e Endpoint) Id() string {
+	return fmt.Sprintf("endpoint %s", e.Label())
+}
+func (e Endpoint) Name() string {
+	if e.Delegate.Ref != nil {
+		return e.Delegate.Ref.Name
+	}
+	return e.Delegate.URI.String()
+}
+
+// Label is the URI, or the kind, namespace and name of the referenced resource, like "KafkaSink other/orders"
+func (e Endpoint) Label() string {
+	ref := e.Delegate.Ref
+	if ref == nil {
+		return e.Delegate.URI.String()
+	}
+	if ref.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", ref.Kind, ref.Namespace, ref.Name)
+	}
+	return fmt.Sprintf("%s %s", ref.Kind, ref.Name)
+}
+func (e Endpoint) Icon() string {
+	return "images/ingress.png"
+}
+func (e Endpoint) StatusColor() (string, bool) {
+	return "", false
+}
+func (e Endpoint) OwnerReferences() []metav1.OwnerReference {
+	return []metav1.OwnerReference{}
+}
+func (e Endpoint) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (e Endpoint) ConnectedKinds() []string {
+	return []string{}
+}
+func (e Endpoint) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
@@ -0,0 +1,67 @@
+package knative
+
+import (
+	"fmt"
+	"strings"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// EventSource models the sources of the kinds discovered from their CRD, like a KafkaSource, collected with the
+// dynamic client
+type EventSource struct {
+	Delegate unstructured.Unstructured
+}
+
+func (s EventSource) Kind() string {
+	return "knative." + s.Delegate.GetKind()
+}
+func (s EventSource) Id() string {
+	return fmt.Sprintf("%s %s", strings.ToLower(s.Delegate.GetKind()), s.Delegate.GetName())
+}
+func (s EventSource) Name() string {
+	return s.Delegate.GetName()
+}
+func (s EventSource) Label() string {
+	return fmt.Sprintf("%s %s", strings.ToLower(s.Delegate.GetKind()), s.Delegate.GetName())
+}
+func (s EventSource) Icon() string {
+	return "images/generic.png"
+}
+func (s EventSource) StatusColor() (string, bool) {
+	return model.UnstructuredResource{Delegate: s.Delegate}.StatusColor()
+}
+func (s EventSource) OwnerReferences() []metav1.OwnerReference {
+	return s.Delegate.GetOwnerReferences()
+}
+func (s EventSource) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return model.IsOwnedBy(owner, s.Delegate.GroupVersionKind().GroupKind(), &s.Delegate)
+}
+func (s EventSource) ConnectedKinds() []string {
+	return []string{}
+}
+func (s EventSource) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
+func (s EventSource) Destinations() []NamedDestination {
+	sink, ok := s.Sink()
+	if !ok {
+		return []NamedDestination{}
+	}
+	return []NamedDestination{{Destination: &sink, Name: "sink"}}
+}
+
+// Sink returns the spec.sink Destination of the source, false if missing or invalid
+func (s EventSource) Sink() (duckv1.Destination, bool) {
+	var sink duckv1.Destination
+	content, found, err := unstructured.NestedMap(s.Delegate.Object, "spec", "sink")
+	if err != nil || !found {
+		return sink, false
+	}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(content, &sink)
+	return sink, err == nil
+}
@@ -0,0 +1,44 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+)
+
+type InMemoryChannel struct {
+	Delegate messagingv1.InMemoryChannel
+}
+
+func (c InMemoryChannel) Kind() string {
+	return "knative.InMemoryChannel"
+}
+func (c InMemoryChannel) Id() string {
+	return fmt.Sprintf("imc %s", c.Delegate.Name)
+}
+func (c InMemoryChannel) Name() string {
+	return c.Delegate.Name
+}
+func (c InMemoryChannel) Label() string {
+	return fmt.Sprintf("imc %s", c.Delegate.Name)
+}
+func (c InMemoryChannel) Icon() string {
+	return "images/generic.png"
+}
+func (c InMemoryChannel) StatusColor() (string, bool) {
+	return statusColorOf(c.Delegate.Status.Status)
+}
+func (c InMemoryChannel) OwnerReferences() []metav1.OwnerReference {
+	return c.Delegate.OwnerReferences
+}
+func (c InMemoryChannel) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (c InMemoryChannel) ConnectedKinds() []string {
+	return []string{}
+}
+func (c InMemoryChannel) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
@@ -0,0 +1,51 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// KafkaChannel models a KafkaChannel of the Knative Kafka extension, collected with the dynamic client
+type KafkaChannel struct {
+	Delegate unstructured.Unstructured
+}
+
+func (c KafkaChannel) Kind() string {
+	return "knative.KafkaChannel"
+}
+func (c KafkaChannel) Id() string {
+	return fmt.Sprintf("kafkachannel %s", c.Delegate.GetName())
+}
+func (c KafkaChannel) Name() string {
+	return c.Delegate.GetName()
+}
+
+// Label adds the number of partitions of the topic, when set
+func (c KafkaChannel) Label() string {
+	partitions, found, _ := unstructured.NestedInt64(c.Delegate.Object, "spec", "numPartitions")
+	if found {
+		return fmt.Sprintf("kafkachannel %s (%d partitions)", c.Delegate.GetName(), partitions)
+	}
+	return fmt.Sprintf("kafkachannel %s", c.Delegate.GetName())
+}
+func (c KafkaChannel) Icon() string {
+	return "images/generic.png"
+}
+func (c KafkaChannel) StatusColor() (string, bool) {
+	return model.UnstructuredResource{Delegate: c.Delegate}.StatusColor()
+}
+func (c KafkaChannel) OwnerReferences() []metav1.OwnerReference {
+	return c.Delegate.GetOwnerReferences()
+}
+func (c KafkaChannel) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (c KafkaChannel) ConnectedKinds() []string {
+	return []string{}
+}
+func (c KafkaChannel) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
@@ -0,0 +1,69 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
+)
+
+type Parallel struct {
+	Delegate flowsv1.Parallel
+}
+
+func (p Parallel) Kind() string {
+	return "knative.Parallel"
+}
+func (p Parallel) Id() string {
+	return fmt.Sprintf("parallel %s", p.Delegate.Name)
+}
+func (p Parallel) Name() string {
+	return p.Delegate.Name
+}
+func (p Parallel) Label() string {
+	return fmt.Sprintf("parallel %s", p.Delegate.Name)
+}
+func (p Parallel) Icon() string {
+	return "images/generic.png"
+}
+func (p Parallel) StatusColor() (string, bool) {
+	return statusColorOf(p.Delegate.Status.Status)
+}
+func (p Parallel) OwnerReferences() []metav1.OwnerReference {
+	return p.Delegate.OwnerReferences
+}
+func (p Parallel) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return model.IsOwnedBy(owner, flowsv1.Kind("Parallel"), &p.Delegate)
+}
+func (p Parallel) ConnectedKinds() []string {
+	return []string{}
+}
+func (p Parallel) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
+
+// Hops chains each branch of the Parallel in order, from the Parallel to the filter, then to the subscriber and
+// finally to the reply of the branch, or of the Parallel. All the hops are named after the Parallel and the branch,
+// like "par branch 1 filter", "par branch 1" or "par branch 1 reply"
+func (p Parallel) Hops() []Hop {
+	hops := make([]Hop, 0)
+	for i := range p.Delegate.Spec.Branches {
+		branch := &p.Delegate.Spec.Branches[i]
+		name := fmt.Sprintf("%s branch %d", p.Delegate.Name, i+1)
+		if branch.Filter != nil {
+			hops = append(hops, Hop{To: branch.Filter, Name: fmt.Sprintf("%s filter", name)})
+			hops = append(hops, Hop{From: branch.Filter, To: &branch.Subscriber, Name: name})
+		} else {
+			hops = append(hops, Hop{To: &branch.Subscriber, Name: name})
+		}
+		reply := branch.Reply
+		if reply == nil {
+			reply = p.Delegate.Spec.Reply
+		}
+		if reply != nil {
+			hops = append(hops, Hop{From: &branch.Subscriber, To: reply, Name: fmt.Sprintf("%s reply", name)})
+		}
+	}
+	return hops
+}
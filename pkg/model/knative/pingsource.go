@@ -0,0 +1,49 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+type PingSource struct {
+	Delegate sourcesv1.PingSource
+}
+
+func (s PingSource) Kind() string {
+	return "knative.PingSource"
+}
+func (s PingSource) Id() string {
+	return fmt.Sprintf("pingsource %s", s.Delegate.Name)
+}
+func (s PingSource) Name() string {
+	return s.Delegate.Name
+}
+
+// Label adds the schedule of the PingSource, like "*/2 * * * *"
+func (s PingSource) Label() string {
+	return fmt.Sprintf("pingsource %s (%s)", s.Delegate.Name, s.Delegate.Spec.Schedule)
+}
+func (s PingSource) Icon() string {
+	return "images/generic.png"
+}
+func (s PingSource) StatusColor() (string, bool) {
+	return statusColorOf(s.Delegate.Status.Status)
+}
+func (s PingSource) OwnerReferences() []metav1.OwnerReference {
+	return s.Delegate.OwnerReferences
+}
+func (s PingSource) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (s PingSource) ConnectedKinds() []string {
+	return []string{}
+}
+func (s PingSource) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
+func (s PingSource) Destinations() []NamedDestination {
+	return []NamedDestination{{Destination: &s.Delegate.Spec.Sink, Name: "sink"}}
+}
@@ -0,0 +1,58 @@
+package knative
+
+import (
+	"fmt"
+	"strings"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/serving/pkg/apis/serving"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+type Revision struct {
+	Delegate servingv1.Revision
+}
+
+func (r Revision) Kind() string {
+	return "knative.Revision"
+}
+func (r Revision) Id() string {
+	return fmt.Sprintf("revision %s", r.Delegate.Name)
+}
+func (r Revision) Name() string {
+	return r.Delegate.Name
+}
+func (r Revision) Label() string {
+	return fmt.Sprintf("revision %s", r.Delegate.Name)
+}
+func (r Revision) Icon() string {
+	return "images/generic.png"
+}
+func (r Revision) StatusColor() (string, bool) {
+	return statusColorOf(r.Delegate.Status.Status)
+}
+func (r Revision) OwnerReferences() []metav1.OwnerReference {
+	return r.Delegate.OwnerReferences
+}
+func (r Revision) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return model.IsOwnedBy(owner, servingv1.Kind("Revision"), &r.Delegate)
+}
+func (r Revision) ConnectedKinds() []string {
+	return []string{"Pod"}
+}
+
+// ConnectedResources returns the Pods labelled with the name of the Revision
+func (r Revision) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	connected := make([]model.Resource, 0)
+	for _, resource := range resources {
+		pod, ok := resource.(model.Pod)
+		if !ok {
+			continue
+		}
+		if strings.Compare(pod.Labels()[serving.RevisionLabelKey], r.Delegate.Name) == 0 {
+			connected = append(connected, pod)
+		}
+	}
+	return connected, "runs"
+}
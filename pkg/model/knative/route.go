@@ -0,0 +1,88 @@
+package knative
+
+import (
+	"fmt"
+	"strings"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+type Route struct {
+	Delegate servingv1.Route
+}
+
+func (r Route) Kind() string {
+	return "knative.Route"
+}
+func (r Route) Id() string {
+	return fmt.Sprintf("kroute %s", r.Delegate.Name)
+}
+func (r Route) Name() string {
+	return r.Delegate.Name
+}
+
+// Label adds the public URL of the Route, when ready
+func (r Route) Label() string {
+	if r.Delegate.Status.URL != nil {
+		return fmt.Sprintf("kroute %s (%s)", r.Delegate.Name, r.Delegate.Status.URL)
+	}
+	return fmt.Sprintf("kroute %s", r.Delegate.Name)
+}
+func (r Route) Icon() string {
+	return "images/ingress.png"
+}
+func (r Route) StatusColor() (string, bool) {
+	return statusColorOf(r.Delegate.Status.Status)
+}
+func (r Route) OwnerReferences() []metav1.OwnerReference {
+	return r.Delegate.OwnerReferences
+}
+func (r Route) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (r Route) ConnectedKinds() []string {
+	return []string{"knative.Revision"}
+}
+
+// ConnectedResources returns the Revisions in the traffic targets of the Route, as resolved in its status
+func (r Route) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	connected := make([]model.Resource, 0)
+	for _, resource := range resources {
+		if r.IsRouted(resource.Name()) {
+			connected = append(connected, resource)
+		}
+	}
+	return connected, "traffic"
+}
+
+// ConnectionName labels the Revisions with their percentage of the traffic and their tags, like "10%, tag canary"
+func (r Route) ConnectionName(to model.Resource) string {
+	var percent int64
+	tags := make([]string, 0)
+	for _, target := range r.trafficOf(to.Name()) {
+		if target.Percent != nil {
+			percent += *target.Percent
+		}
+		if target.Tag != "" {
+			tags = append(tags, fmt.Sprintf("tag %s", target.Tag))
+		}
+	}
+	return strings.Join(append([]string{fmt.Sprintf("%d%%", percent)}, tags...), ", ")
+}
+
+// IsRouted returns true if the given Revision is a traffic target of the Route
+func (r Route) IsRouted(revisionName string) bool {
+	return len(r.trafficOf(revisionName)) > 0
+}
+
+func (r Route) trafficOf(revisionName string) []servingv1.TrafficTarget {
+	targets := make([]servingv1.TrafficTarget, 0)
+	for _, target := range r.Delegate.Status.Traffic {
+		if strings.Compare(target.RevisionName, revisionName) == 0 {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
@@ -0,0 +1,62 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+type Sequence struct {
+	Delegate flowsv1.Sequence
+}
+
+func (s Sequence) Kind() string {
+	return "knative.Sequence"
+}
+func (s Sequence) Id() string {
+	return fmt.Sprintf("sequence %s", s.Delegate.Name)
+}
+func (s Sequence) Name() string {
+	return s.Delegate.Name
+}
+func (s Sequence) Label() string {
+	return fmt.Sprintf("sequence %s", s.Delegate.Name)
+}
+func (s Sequence) Icon() string {
+	return "images/generic.png"
+}
+func (s Sequence) StatusColor() (string, bool) {
+	return statusColorOf(s.Delegate.Status.Status)
+}
+func (s Sequence) OwnerReferences() []metav1.OwnerReference {
+	return s.Delegate.OwnerReferences
+}
+func (s Sequence) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return model.IsOwnedBy(owner, flowsv1.Kind("Sequence"), &s.Delegate)
+}
+func (s Sequence) ConnectedKinds() []string {
+	return []string{}
+}
+func (s Sequence) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
+
+// Hops chains the steps of the Sequence in order, from the Sequence to its first step and from its last step to its reply.
+// All the hops are named after the Sequence, like "seq step 2" or "seq reply", as they may connect resources shared by
+// other flows
+func (s Sequence) Hops() []Hop {
+	hops := make([]Hop, 0)
+	var previous *duckv1.Destination
+	for i := range s.Delegate.Spec.Steps {
+		step := &s.Delegate.Spec.Steps[i].Destination
+		hops = append(hops, Hop{From: previous, To: step, Name: fmt.Sprintf("%s step %d", s.Delegate.Name, i+1)})
+		previous = step
+	}
+	if s.Delegate.Spec.Reply != nil {
+		hops = append(hops, Hop{From: previous, To: s.Delegate.Spec.Reply, Name: fmt.Sprintf("%s reply", s.Delegate.Name)})
+	}
+	return hops
+}
@@ -2,7 +2,6 @@ package knative
 
 import (
 	"fmt"
-	"strings"
 
 	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,21 +24,20 @@ func (s Service) Name() string {
 func (s Service) Label() string {
 	return fmt.Sprintf("ksvc %s", s.Delegate.Name)
 }
+func (s Service) Labels() map[string]string {
+	return s.Delegate.Labels
+}
 func (s Service) Icon() string {
 	return "images/svc.png"
 }
 func (s Service) StatusColor() (string, bool) {
-	return "", false
+	return statusColorOf(s.Delegate.Status.Status)
 }
 func (s Service) OwnerReferences() []metav1.OwnerReference {
 	return s.Delegate.OwnerReferences
 }
 func (s Service) IsOwnerOf(owner metav1.OwnerReference) bool {
-	switch owner.Kind {
-	case "Revision":
-		return strings.HasPrefix(owner.Name, s.Name())
-	}
-	return false
+	return model.IsOwnedBy(owner, servingv1.Kind("Service"), &s.Delegate)
 }
 func (s Service) ConnectedKinds() []string {
 	return []string{}
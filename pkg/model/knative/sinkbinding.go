@@ -2,11 +2,11 @@ package knative
 
 import (
 	"fmt"
-	"strings"
 
 	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 type SinkBinding struct {
@@ -29,7 +29,7 @@ func (s SinkBinding) Icon() string {
 	return "images/generic.png"
 }
 func (s SinkBinding) StatusColor() (string, bool) {
-	return "", false
+	return statusColorOf(s.Delegate.Status.Status)
 }
 func (s SinkBinding) OwnerReferences() []metav1.OwnerReference {
 	return s.Delegate.OwnerReferences
@@ -38,33 +38,19 @@ func (s SinkBinding) IsOwnerOf(owner metav1.OwnerReference) bool {
 	return false
 }
 func (s SinkBinding) ConnectedKinds() []string {
-	return []string{"knative.Service", "knative.Broker"}
+	return []string{}
 }
 func (s SinkBinding) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
-	connected := make([]model.Resource, 0)
-	connectionName := ""
-	for _, resource := range resources {
+	return []model.Resource{}, ""
+}
 
-		if strings.Compare(resource.Kind(), "knative.Service") == 0 {
-			if strings.Compare(s.Delegate.Spec.BindingSpec.Subject.Kind, "Service") == 0 {
-				service := resource.(Service)
-				serviceName := s.Delegate.Spec.BindingSpec.Subject.Name
-				if strings.Compare(serviceName, service.Name()) == 0 {
-					connected = append(connected, service)
-				}
-			}
-			connectionName = "subject"
-		} else if strings.Compare(resource.Kind(), "knative.Broker") == 0 {
-			if strings.Compare(s.Delegate.Spec.Sink.Ref.Kind, "Broker") == 0 {
-				broker := resource.(Broker)
-				brokerName := s.Delegate.Spec.Sink.Ref.Name
-				if strings.Compare(brokerName, broker.Name()) == 0 {
-					connected = append(connected, broker)
-				}
-			}
-			connectionName = "sink"
-		}
+// Destinations returns the subject of the SinkBinding, when referenced by name, and its sink
+func (s SinkBinding) Destinations() []NamedDestination {
+	destinations := make([]NamedDestination, 0)
+	subject := s.Delegate.Spec.BindingSpec.Subject
+	if subject.Name != "" {
+		ref := &duckv1.KReference{APIVersion: subject.APIVersion, Kind: subject.Kind, Namespace: subject.Namespace, Name: subject.Name}
+		destinations = append(destinations, NamedDestination{Destination: &duckv1.Destination{Ref: ref}, Name: "subject"})
 	}
-
-	return connected, connectionName
+	return append(destinations, NamedDestination{Destination: &s.Delegate.Spec.Sink, Name: "sink"})
 }
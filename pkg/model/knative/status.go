@@ -0,0 +1,15 @@
+package knative
+
+import (
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// statusColorOf derives the status from the Ready condition of the Knative resources
+func statusColorOf(status duckv1.Status) (string, bool) {
+	conditions := make([]model.Condition, 0)
+	for _, c := range status.Conditions {
+		conditions = append(conditions, model.Condition{Type: string(c.Type), Status: string(c.Status), Reason: c.Reason})
+	}
+	return model.ConditionsStatusColor(conditions)
+}
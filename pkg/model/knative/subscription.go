@@ -0,0 +1,58 @@
+package knative
+
+import (
+	"fmt"
+
+	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+type Subscription struct {
+	Delegate messagingv1.Subscription
+}
+
+func (s Subscription) Kind() string {
+	return "knative.Subscription"
+}
+func (s Subscription) Id() string {
+	return fmt.Sprintf("subscription %s", s.Delegate.Name)
+}
+func (s Subscription) Name() string {
+	return s.Delegate.Name
+}
+func (s Subscription) Label() string {
+	return fmt.Sprintf("subscription %s", s.Delegate.Name)
+}
+func (s Subscription) Icon() string {
+	return "images/generic.png"
+}
+func (s Subscription) StatusColor() (string, bool) {
+	return statusColorOf(s.Delegate.Status.Status)
+}
+func (s Subscription) OwnerReferences() []metav1.OwnerReference {
+	return s.Delegate.OwnerReferences
+}
+func (s Subscription) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (s Subscription) ConnectedKinds() []string {
+	return []string{}
+}
+func (s Subscription) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
+	return []model.Resource{}, ""
+}
+
+// Destinations returns the Channel of the Subscription and its subscriber, reply and dead letter sink
+func (s Subscription) Destinations() []NamedDestination {
+	spec := s.Delegate.Spec
+	destinations := []NamedDestination{{Destination: &duckv1.Destination{Ref: &spec.Channel}, Name: "channel"}}
+	if spec.Subscriber != nil {
+		destinations = append(destinations, NamedDestination{Destination: spec.Subscriber, Name: "subscriber"})
+	}
+	if spec.Reply != nil {
+		destinations = append(destinations, NamedDestination{Destination: spec.Reply, Name: model.ReplyConnection})
+	}
+	return append(destinations, deadLetterSinkOf(spec.Delivery)...)
+}
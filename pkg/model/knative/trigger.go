@@ -2,6 +2,7 @@ package knative
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	model "github.com/dmartinol/openshift-topology-exporter/pkg/model"
@@ -29,7 +30,7 @@ func (t Trigger) Icon() string {
 	return "images/generic.png"
 }
 func (t Trigger) StatusColor() (string, bool) {
-	return "", false
+	return statusColorOf(t.Delegate.Status.Status)
 }
 func (t Trigger) OwnerReferences() []metav1.OwnerReference {
 	return t.Delegate.OwnerReferences
@@ -38,31 +39,36 @@ func (t Trigger) IsOwnerOf(owner metav1.OwnerReference) bool {
 	return false
 }
 func (t Trigger) ConnectedKinds() []string {
-	return []string{"knative.Service", "knative.Broker"}
+	return []string{"knative.Broker"}
 }
 func (t Trigger) ConnectedResources(kind string, resources []model.Resource) ([]model.Resource, string) {
 	connected := make([]model.Resource, 0)
-	connectionName := ""
 	for _, resource := range resources {
-
-		if strings.Compare(resource.Kind(), "knative.Service") == 0 {
-			if strings.Compare(t.Delegate.Spec.Subscriber.Ref.Kind, "Service") == 0 {
-				service := resource.(Service)
-				serviceName := t.Delegate.Spec.Subscriber.Ref.Name
-				if strings.Compare(serviceName, service.Name()) == 0 {
-					connected = append(connected, service)
-				}
-			}
-			connectionName = "subscriber"
-		} else if strings.Compare(resource.Kind(), "knative.Broker") == 0 {
-			broker := resource.(Broker)
-			brokerName := t.Delegate.Spec.Broker
-			if strings.Compare(brokerName, broker.Name()) == 0 {
-				connected = append(connected, broker)
-			}
-			connectionName = "broker"
+		if strings.Compare(t.Delegate.Spec.Broker, resource.Name()) == 0 {
+			connected = append(connected, resource)
 		}
 	}
+	return connected, "broker"
+}
 
-	return connected, connectionName
+// Destinations returns the subscriber of the Trigger, named after the filtered attributes, and its dead letter sink
+func (t Trigger) Destinations() []NamedDestination {
+	subscriber := NamedDestination{Destination: &t.Delegate.Spec.Subscriber, Name: "subscriber"}
+	if filter := t.FilterLabel(); filter != "" {
+		subscriber.Name = filter
+	}
+	return append([]NamedDestination{subscriber}, deadLetterSinkOf(t.Delegate.Spec.Delivery)...)
+}
+
+// FilterLabel returns the attributes filtered by the Trigger, sorted by name, like "source=orders, type=created"
+func (t Trigger) FilterLabel() string {
+	if t.Delegate.Spec.Filter == nil {
+		return ""
+	}
+	attributes := make([]string, 0, len(t.Delegate.Spec.Filter.Attributes))
+	for name, value := range t.Delegate.Spec.Filter.Attributes {
+		attributes = append(attributes, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(attributes)
+	return strings.Join(attributes, ", ")
 }
@@ -3,27 +3,42 @@ package model
 import (
 	"reflect"
 	"strings"
+	"sync"
 
 	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// NamespaceModel holds the resources and connections of a namespace, it is safe for concurrent use
 type NamespaceModel struct {
+	lock            sync.RWMutex
 	name            string
 	resourcesByKind map[string][]Resource
 	connections     []Connection
+	events          Events
+	warnings        []string
+	// The resources left out of the topology on purpose, like the inactive Revisions
+	skipped []Resource
 }
 
-func (namespace NamespaceModel) Debug(header string) string {
+func (namespace *NamespaceModel) Debug(header string) string {
+	namespace.lock.RLock()
+	defer namespace.lock.RUnlock()
 	logger.Debugf("[%s] Sizes for %s are %d, %d", header, namespace.name, len(namespace.resourcesByKind), len(namespace.connections))
 	return namespace.name
 }
 
-func (namespace NamespaceModel) Name() string {
+func (namespace *NamespaceModel) Name() string {
 	return namespace.name
 }
 
-func (namespace NamespaceModel) LookupByKindAndId(kind string, id string) Resource {
+func (namespace *NamespaceModel) LookupByKindAndId(kind string, id string) Resource {
+	namespace.lock.RLock()
+	defer namespace.lock.RUnlock()
+	return namespace.lookupByKindAndId(kind, id)
+}
+
+func (namespace *NamespaceModel) lookupByKindAndId(kind string, id string) Resource {
 	for _, resource := range namespace.resourcesByKind[kind] {
 		if strings.Compare(id, resource.Id()) == 0 {
 			return resource
@@ -33,8 +48,10 @@ func (namespace NamespaceModel) LookupByKindAndId(kind string, id string) Resour
 	return nil
 }
 
-func (namespace NamespaceModel) AddResource(resource Resource) bool {
-	if namespace.LookupByKindAndId(resource.Kind(), resource.Id()) == nil {
+func (namespace *NamespaceModel) AddResource(resource Resource) bool {
+	namespace.lock.Lock()
+	defer namespace.lock.Unlock()
+	if namespace.lookupByKindAndId(resource.Kind(), resource.Id()) == nil {
 		logger.Debugf("Adding resource %s of kind %s", resource.Name(), resource.Kind())
 		namespace.resourcesByKind[resource.Kind()] = append(namespace.resourcesByKind[resource.Kind()], resource)
 		return true
@@ -42,27 +59,40 @@ func (namespace NamespaceModel) AddResource(resource Resource) bool {
 	logger.Debugf("Skipped existing resource %s of kind %s", resource.Name(), resource.Kind())
 	return false
 }
-func (namespace NamespaceModel) LookupOwner(owner metav1.OwnerReference) Resource {
-	for _, resources := range namespace.resourcesByKind {
-		for _, resource := range resources {
-			if resource.IsOwnerOf(owner) {
-				return resource
-			}
+
+// LookupOwner returns the resource matching the given owner reference, or nil if not found
+func (namespace *NamespaceModel) LookupOwner(owner metav1.OwnerReference) Resource {
+	for _, resource := range namespace.AllResources() {
+		if resource.IsOwnerOf(owner) {
+			return resource
 		}
 	}
+	return nil
+}
 
-	if strings.Compare(owner.Kind, "ClusterServiceVersion") == 0 {
-		csvResource := ClusterServiceVersion{Delegate: owner}
-		namespace.AddResource(csvResource)
-		return csvResource
-	} else {
-		// TODO Just guessing ....
-		customResource := CustomResource{Delegate: owner}
-		namespace.AddResource(customResource)
-		return customResource
+// SkipResource records a resource that is left out of the topology on purpose, so that the resources it owns can be
+// left out too
+func (namespace *NamespaceModel) SkipResource(resource Resource) {
+	namespace.lock.Lock()
+	defer namespace.lock.Unlock()
+	logger.Debugf("Skipping resource %s of kind %s", resource.Name(), resource.Kind())
+	namespace.skipped = append(namespace.skipped, resource)
+}
+
+// IsSkippedOwner returns true if the given owner reference matches a resource left out of the topology on purpose
+func (namespace *NamespaceModel) IsSkippedOwner(owner metav1.OwnerReference) bool {
+	namespace.lock.RLock()
+	defer namespace.lock.RUnlock()
+	for _, resource := range namespace.skipped {
+		if resource.IsOwnerOf(owner) {
+			return true
+		}
 	}
+	return false
 }
-func (namespace NamespaceModel) AllKinds() []string {
+func (namespace *NamespaceModel) AllKinds() []string {
+	namespace.lock.RLock()
+	defer namespace.lock.RUnlock()
 	keys := make([]string, 0, len(namespace.resourcesByKind))
 	for k := range namespace.resourcesByKind {
 		keys = append(keys, k)
@@ -70,10 +100,14 @@ func (namespace NamespaceModel) AllKinds() []string {
 	return keys
 }
 
-func (namespace NamespaceModel) ResourcesByKind(kind string) []Resource {
-	return namespace.resourcesByKind[kind]
+func (namespace *NamespaceModel) ResourcesByKind(kind string) []Resource {
+	namespace.lock.RLock()
+	defer namespace.lock.RUnlock()
+	return append([]Resource{}, namespace.resourcesByKind[kind]...)
 }
-func (namespace NamespaceModel) AllResources() []Resource {
+func (namespace *NamespaceModel) AllResources() []Resource {
+	namespace.lock.RLock()
+	defer namespace.lock.RUnlock()
 	resources := make([]Resource, 0)
 	for kind := range namespace.resourcesByKind {
 		resources = append(resources, namespace.resourcesByKind[kind]...)
@@ -81,24 +115,117 @@ func (namespace NamespaceModel) AllResources() []Resource {
 	return resources
 }
 
-func (namespace *NamespaceModel) AddConnection(from Resource, to Resource) *Connection {
+func (namespace *NamespaceModel) AddConnection(from Resource, to Resource) {
+	namespace.lock.Lock()
+	defer namespace.lock.Unlock()
+	namespace.addConnection(from, to, "")
+}
+func (namespace *NamespaceModel) AddNamedConnection(from Resource, to Resource, name string) {
+	namespace.lock.Lock()
+	defer namespace.lock.Unlock()
+	namespace.addConnection(from, to, name)
+}
+
+func (namespace *NamespaceModel) addConnection(from Resource, to Resource, name string) {
 	for _, c := range namespace.connections {
 		if reflect.DeepEqual(c.From, from) && reflect.DeepEqual(c.To, to) {
 			logger.Debugf("Skipped existing connection from %s of kind %s and %s of kind %s", from.Name(), from.Kind(), to.Name(), to.Kind())
-			return &c
+			return
 		}
 	}
 
-	connection := Connection{From: from, To: to}
+	connection := Connection{From: from, To: to, Name: name}
 	namespace.connections = append(namespace.connections, connection)
-	return &namespace.connections[len(namespace.connections)-1]
 }
-func (namespace *NamespaceModel) AddNamedConnection(from Resource, to Resource, name string) *Connection {
-	connection := namespace.AddConnection(from, to)
-	connection.Name = name
-	return connection
+
+// RemoveResource removes the given resource and all its connections
+func (namespace *NamespaceModel) RemoveResource(resource Resource) {
+	namespace.lock.Lock()
+	defer namespace.lock.Unlock()
+	resources := make([]Resource, 0)
+	for _, r := range namespace.resourcesByKind[resource.Kind()] {
+		if strings.Compare(r.Id(), resource.Id()) != 0 {
+			resources = append(resources, r)
+		}
+	}
+	namespace.resourcesByKind[resource.Kind()] = resources
+	connections := make([]Connection, 0)
+	for _, c := range namespace.connections {
+		if !reflect.DeepEqual(c.From, resource) && !reflect.DeepEqual(c.To, resource) {
+			connections = append(connections, c)
+		}
+	}
+	namespace.connections = connections
+}
+
+// MergeResource replaces the given resource with another one, possibly of the same kind and id, moving its
+// connections to the replacement. The connections that become loops or duplicates are dropped
+func (namespace *NamespaceModel) MergeResource(resource Resource, into Resource) {
+	namespace.lock.Lock()
+	defer namespace.lock.Unlock()
+	resources := make([]Resource, 0)
+	for _, r := range namespace.resourcesByKind[resource.Kind()] {
+		if strings.Compare(r.Id(), resource.Id()) != 0 {
+			resources = append(resources, r)
+		}
+	}
+	namespace.resourcesByKind[resource.Kind()] = resources
+	if events := namespace.events.Of(resource); len(events) > 0 {
+		delete(namespace.events, eventsKey(resource))
+		namespace.events.add(into, events...)
+	}
+	if namespace.lookupByKindAndId(into.Kind(), into.Id()) == nil {
+		namespace.resourcesByKind[into.Kind()] = append(namespace.resourcesByKind[into.Kind()], into)
+	}
+
+	connections := namespace.connections
+	namespace.connections = make([]Connection, 0, len(connections))
+	for _, c := range connections {
+		if reflect.DeepEqual(c.From, resource) {
+			c.From = into
+		}
+		if reflect.DeepEqual(c.To, resource) {
+			c.To = into
+		}
+		if !reflect.DeepEqual(c.From, c.To) {
+			namespace.addConnection(c.From, c.To, c.Name)
+		}
+	}
+}
+
+func (namespace *NamespaceModel) AllConnections() []Connection {
+	namespace.lock.RLock()
+	defer namespace.lock.RUnlock()
+	return append([]Connection{}, namespace.connections...)
+}
+
+// AddEvent attaches an Event to the given resource
+func (namespace *NamespaceModel) AddEvent(resource Resource, event Event) {
+	namespace.lock.Lock()
+	defer namespace.lock.Unlock()
+	namespace.events.add(resource, event)
+}
+
+// Events returns the Events of the resources, the latest first
+func (namespace *NamespaceModel) Events() Events {
+	namespace.lock.RLock()
+	defer namespace.lock.RUnlock()
+	events := make(Events)
+	for key, resourceEvents := range namespace.events {
+		events[key] = append([]Event{}, resourceEvents...)
+	}
+	return events
+}
+
+// AddWarning records a problem that occurred while collecting the namespace, like a kind that could not be listed
+func (namespace *NamespaceModel) AddWarning(warning string) {
+	namespace.lock.Lock()
+	defer namespace.lock.Unlock()
+	namespace.warnings = append(namespace.warnings, warning)
 }
 
-func (namespace NamespaceModel) AllConnections() []Connection {
-	return namespace.connections
+func (namespace *NamespaceModel) Warnings() []string {
+	namespace.lock.RLock()
+	defer namespace.lock.RUnlock()
+	return append([]string{}, namespace.warnings...)
 }
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NetworkPolicy struct {
+	Delegate v1.NetworkPolicy
+}
+
+func (n NetworkPolicy) Kind() string {
+	return "NetworkPolicy"
+}
+func (n NetworkPolicy) Id() string {
+	return fmt.Sprintf("netpol %s", n.Delegate.Name)
+}
+func (n NetworkPolicy) Name() string {
+	return n.Delegate.Name
+}
+func (n NetworkPolicy) Label() string {
+	types := make([]string, 0, len(n.Delegate.Spec.PolicyTypes))
+	for _, policyType := range n.Delegate.Spec.PolicyTypes {
+		types = append(types, strings.ToLower(string(policyType)))
+	}
+	if len(types) == 0 {
+		return n.Delegate.Name
+	}
+	return fmt.Sprintf("%s (%s)", n.Delegate.Name, strings.Join(types, ", "))
+}
+func (n NetworkPolicy) Labels() map[string]string {
+	return n.Delegate.Labels
+}
+func (n NetworkPolicy) Icon() string {
+	return "images/generic.png"
+}
+func (n NetworkPolicy) StatusColor() (string, bool) {
+	return "", false
+}
+func (n NetworkPolicy) OwnerReferences() []metav1.OwnerReference {
+	return n.Delegate.OwnerReferences
+}
+func (n NetworkPolicy) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (n NetworkPolicy) ConnectedKinds() []string {
+	return []string{}
+}
+func (n NetworkPolicy) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
+
+// Selects returns true if the policy applies to the Pod with the given labels
+func (n NetworkPolicy) Selects(podLabels map[string]string) bool {
+	return MatchesLabelSelector(&n.Delegate.Spec.PodSelector, podLabels)
+}
+
+// AppliesTo returns true if the policy restricts the given traffic direction, either Ingress or Egress
+func (n NetworkPolicy) AppliesTo(policyType v1.PolicyType) bool {
+	if len(n.Delegate.Spec.PolicyTypes) == 0 {
+		// Default policy types: Ingress, and Egress only if there are egress rules
+		return policyType == v1.PolicyTypeIngress || len(n.Delegate.Spec.Egress) > 0
+	}
+	for _, t := range n.Delegate.Spec.PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsPeer returns true if the given peer matches the Pod and the namespace with the given labels.
+// Peers with only an IP block never match a Pod
+func AllowsPeer(peer v1.NetworkPolicyPeer, podLabels map[string]string, namespaceLabels map[string]string, sameNamespace bool) bool {
+	if peer.PodSelector == nil && peer.NamespaceSelector == nil {
+		return false
+	}
+	if peer.NamespaceSelector == nil {
+		if !sameNamespace {
+			return false
+		}
+	} else if !MatchesLabelSelector(peer.NamespaceSelector, namespaceLabels) {
+		return false
+	}
+	return peer.PodSelector == nil || MatchesLabelSelector(peer.PodSelector, podLabels)
+}
+
+// PortsLabel describes the allowed ports, like TCP/8080, or "any port" when not restricted
+func PortsLabel(ports []v1.NetworkPolicyPort) string {
+	if len(ports) == 0 {
+		return "any port"
+	}
+	labels := make([]string, 0, len(ports))
+	for _, port := range ports {
+		protocol := "TCP"
+		if port.Protocol != nil {
+			protocol = string(*port.Protocol)
+		}
+		label := protocol
+		if port.Port != nil {
+			label = fmt.Sprintf("%s/%s", protocol, port.Port.String())
+			if port.EndPort != nil {
+				label = fmt.Sprintf("%s-%d", label, *port.EndPort)
+			}
+		}
+		labels = append(labels, label)
+	}
+	return strings.Join(labels, ", ")
+}
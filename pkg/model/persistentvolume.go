@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PersistentVolume struct {
+	Delegate v1.PersistentVolume
+}
+
+func (p PersistentVolume) Kind() string {
+	return "PersistentVolume"
+}
+func (p PersistentVolume) Id() string {
+	return fmt.Sprintf("pv %s", p.Delegate.Name)
+}
+func (p PersistentVolume) Name() string {
+	return p.Delegate.Name
+}
+func (p PersistentVolume) Label() string {
+	if capacity, ok := p.Delegate.Spec.Capacity[v1.ResourceStorage]; ok {
+		return fmt.Sprintf("%s (%s)", p.Delegate.Name, capacity.String())
+	}
+	return p.Delegate.Name
+}
+func (p PersistentVolume) Labels() map[string]string {
+	return p.Delegate.Labels
+}
+func (p PersistentVolume) Icon() string {
+	return "images/generic.png"
+}
+func (p PersistentVolume) StatusColor() (string, bool) {
+	switch p.Delegate.Status.Phase {
+	case v1.VolumePending, v1.VolumeReleased:
+		return WarningColor, true
+	case v1.VolumeFailed:
+		return FailedColor, true
+	}
+	return "", false
+}
+func (p PersistentVolume) OwnerReferences() []metav1.OwnerReference {
+	return p.Delegate.OwnerReferences
+}
+func (p PersistentVolume) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (p PersistentVolume) ConnectedKinds() []string {
+	return []string{"StorageClass"}
+}
+func (p PersistentVolume) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	connected := make([]Resource, 0)
+	for _, resource := range resources {
+		if resource.Name() == p.Delegate.Spec.StorageClassName {
+			connected = append(connected, resource)
+		}
+	}
+	return connected, ""
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PersistentVolumeClaim struct {
+	Delegate v1.PersistentVolumeClaim
+}
+
+func (p PersistentVolumeClaim) Kind() string {
+	return "PersistentVolumeClaim"
+}
+func (p PersistentVolumeClaim) Id() string {
+	// Qualified with the namespace, as the claims are connected to the cluster-scoped PersistentVolumes
+	return fmt.Sprintf("pvc %s/%s", p.Delegate.Namespace, p.Delegate.Name)
+}
+func (p PersistentVolumeClaim) Name() string {
+	return p.Delegate.Name
+}
+func (p PersistentVolumeClaim) Label() string {
+	if capacity, ok := p.Delegate.Status.Capacity[v1.ResourceStorage]; ok {
+		return fmt.Sprintf("%s (%s)", p.Delegate.Name, capacity.String())
+	}
+	return p.Delegate.Name
+}
+func (p PersistentVolumeClaim) Labels() map[string]string {
+	return p.Delegate.Labels
+}
+func (p PersistentVolumeClaim) Icon() string {
+	return "images/generic.png"
+}
+func (p PersistentVolumeClaim) StatusColor() (string, bool) {
+	switch p.Delegate.Status.Phase {
+	case v1.ClaimPending:
+		return WarningColor, true
+	case v1.ClaimLost:
+		return FailedColor, true
+	}
+	return "", false
+}
+func (p PersistentVolumeClaim) OwnerReferences() []metav1.OwnerReference {
+	return p.Delegate.OwnerReferences
+}
+func (p PersistentVolumeClaim) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (p PersistentVolumeClaim) ConnectedKinds() []string {
+	return []string{}
+}
+func (p PersistentVolumeClaim) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
+
+// IsGeneratedFrom returns true if the claim was created by the StatefulSet from the given volumeClaimTemplate,
+// as <template>-<statefulset>-<ordinal>
+func (p PersistentVolumeClaim) IsGeneratedFrom(statefulSet string, template string) bool {
+	pattern := fmt.Sprintf("^%s-%s-[0-9]+$", regexp.QuoteMeta(template), regexp.QuoteMeta(statefulSet))
+	matched, _ := regexp.MatchString(pattern, p.Delegate.Name)
+	return matched
+}
+
+func connectedClaims(claimNames []string, resources []Resource) []Resource {
+	connected := make([]Resource, 0)
+	for _, resource := range resources {
+		if containsString(claimNames, resource.Name()) {
+			connected = append(connected, resource)
+		}
+	}
+	return connected
+}
+
+func isReferenceToAny(dangling DanglingReference, kind string, names []string) bool {
+	for _, name := range names {
+		if dangling.IsReferenceTo(kind, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -12,10 +12,38 @@ const (
 	CompletedColor = "#66ff33"
 	RunningColor   = "#00ffff"
 	FailedColor    = "#ff3300"
+	WarningColor   = "#ffff99"
+	// ProgressingColor is the status of the resources being rolled out or reconciled
+	ProgressingColor = "#99ccff"
+	MissingColor     = "#cccccc"
+	// PendingColor is the status of the Pods waiting to be scheduled or for their containers to be created
+	PendingColor = "#ffcc66"
+	// BackOffColor is the status of the Pods whose containers cannot start, or are restarted after crashing
+	BackOffColor     = "#ff9966"
+	TerminatingColor = "#cc99ff"
+	// EvictedColor is the status of the Pods evicted from their node, like under memory or disk pressure
+	EvictedColor = "#cc9966"
+)
+
+// The reasons of the Pod states, as displayed by kubectl
+const (
+	PendingReason           = "Pending"
+	ContainerCreatingReason = "ContainerCreating"
+	PodInitializingReason   = "PodInitializing"
+	CrashLoopBackOffReason  = "CrashLoopBackOff"
+	ImagePullBackOffReason  = "ImagePullBackOff"
+	ErrImagePullReason      = "ErrImagePull"
+	OOMKilledReason         = "OOMKilled"
+	TerminatingReason       = "Terminating"
+	EvictedReason           = "Evicted"
 )
 
 type Pod struct {
 	Delegate v1.Pod
+	// Unrestricted is set when the namespace has NetworkPolicies but none of them selects the Pod
+	Unrestricted bool
+	// Usage is the CPU and memory usage of the Pod, nil when the metrics were not collected
+	Usage *Utilization
 }
 
 func (d Pod) Kind() string {
@@ -28,7 +56,34 @@ func (p Pod) Name() string {
 	return p.Delegate.Name
 }
 func (p Pod) Label() string {
-	return p.Delegate.Name
+	details := make([]string, 0)
+	if reason := p.Reason(); reason != "" {
+		details = append(details, reason)
+	}
+	if restarts := p.Restarts(); restarts == 1 {
+		details = append(details, "1 restart")
+	} else if restarts > 1 {
+		details = append(details, fmt.Sprintf("%d restarts", restarts))
+	}
+	if p.Usage != nil {
+		details = append(details, p.Usage.String())
+	}
+	if p.Unrestricted {
+		details = append(details, "unrestricted")
+	}
+	if len(details) == 0 {
+		return p.Delegate.Name
+	}
+	return fmt.Sprintf("%s (%s)", p.Delegate.Name, strings.Join(details, ", "))
+}
+func (p Pod) Labels() map[string]string {
+	return p.Delegate.Labels
+}
+func (p Pod) Utilization() (Utilization, bool) {
+	if p.Usage == nil {
+		return Utilization{}, false
+	}
+	return *p.Usage, true
 }
 func (p Pod) Icon() string {
 	return "images/pod.png"
@@ -40,21 +95,58 @@ func (p Pod) IsOwnerOf(owner metav1.OwnerReference) bool {
 	return false
 }
 func (p Pod) ConnectedKinds() []string {
-	return []string{"ServiceAccount"}
+	return []string{"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim", DanglingReferenceKind}
 }
 func (p Pod) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
-	connected := make([]Resource, 0)
+	if strings.Compare(kind, "PersistentVolumeClaim") == 0 {
+		return connectedClaims(p.ClaimNames(), resources), "volume"
+	}
+	connected := connectedConfigResources(p.ConfigReferences(), resources)
 	for _, resource := range resources {
-		serviceAccount := resource.(ServiceAccount)
-		if strings.Compare(p.Delegate.Spec.ServiceAccountName, serviceAccount.Name()) == 0 &&
-			strings.Compare(p.Delegate.Namespace, serviceAccount.Delegate.Namespace) == 0 {
-			connected = append(connected, serviceAccount)
+		switch resource := resource.(type) {
+		case ServiceAccount:
+			if strings.Compare(p.Delegate.Spec.ServiceAccountName, resource.Name()) == 0 &&
+				strings.Compare(p.Delegate.Namespace, resource.Delegate.Namespace) == 0 {
+				connected = append(connected, resource)
+			}
+		case DanglingReference:
+			if resource.IsReferenceTo("ServiceAccount", p.Delegate.Spec.ServiceAccountName) ||
+				isReferenceToAny(resource, "PersistentVolumeClaim", p.ClaimNames()) {
+				connected = append(connected, resource)
+			}
 		}
 	}
 	return connected, ""
 }
+func (p Pod) ConfigReferences() []ConfigReference {
+	return ConfigReferencesOf(p.Delegate.Spec)
+}
+
+// ClaimNames returns the names of the PersistentVolumeClaims mounted by the Pod
+func (p Pod) ClaimNames() []string {
+	names := make([]string, 0)
+	for _, volume := range p.Delegate.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			names = append(names, volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	return names
+}
+func (p Pod) ConnectionName(to Resource) string {
+	return configConnectionName(p.ConfigReferences(), to)
+}
 
 func (p Pod) StatusColor() (string, bool) {
+	switch p.Reason() {
+	case TerminatingReason:
+		return TerminatingColor, true
+	case EvictedReason:
+		return EvictedColor, true
+	case PendingReason, ContainerCreatingReason, PodInitializingReason:
+		return PendingColor, true
+	case CrashLoopBackOffReason, ImagePullBackOffReason, ErrImagePullReason, OOMKilledReason:
+		return BackOffColor, true
+	}
 	switch p.Delegate.Status.Phase {
 	case "Succeeded":
 		return CompletedColor, true
@@ -74,3 +166,42 @@ func (p Pod) isReady() bool {
 	}
 	return false
 }
+
+// Reason returns the reason why the Pod is not running normally, like CrashLoopBackOff or Evicted, or an empty string
+func (p Pod) Reason() string {
+	if p.Delegate.DeletionTimestamp != nil {
+		return TerminatingReason
+	}
+	if p.Delegate.Status.Phase == v1.PodFailed && p.Delegate.Status.Reason != "" {
+		return p.Delegate.Status.Reason
+	}
+	statuses := append(append([]v1.ContainerStatus{}, p.Delegate.Status.InitContainerStatuses...),
+		p.Delegate.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" {
+			if terminated := status.LastTerminationState.Terminated; waiting.Reason == CrashLoopBackOffReason &&
+				terminated != nil && terminated.Reason == OOMKilledReason {
+				return OOMKilledReason
+			}
+			return waiting.Reason
+		}
+		if terminated := status.State.Terminated; terminated != nil && terminated.Reason == OOMKilledReason {
+			return OOMKilledReason
+		}
+	}
+	if p.Delegate.Status.Phase == v1.PodPending {
+		return PendingReason
+	}
+	return ""
+}
+
+// Restarts returns the number of restarts of all the containers of the Pod, including the init containers
+func (p Pod) Restarts() int32 {
+	restarts := int32(0)
+	statuses := append(append([]v1.ContainerStatus{}, p.Delegate.Status.InitContainerStatuses...),
+		p.Delegate.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func waiting(reason string) v1.ContainerStatus {
+	return v1.ContainerStatus{State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason}}}
+}
+
+func TestPodReason(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	tests := []struct {
+		name      string
+		pod       v1.Pod
+		want      string
+		wantColor string
+	}{
+		{"running", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}}}, "", RunningColor},
+		{"running but not ready", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}}}}, "", FailedColor},
+		{"succeeded", v1.Pod{Status: v1.PodStatus{Phase: v1.PodSucceeded}}, "", CompletedColor},
+		{"unscheduled", v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending}}, PendingReason, PendingColor},
+		{"creating", v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending,
+			ContainerStatuses: []v1.ContainerStatus{waiting(ContainerCreatingReason)}}}, ContainerCreatingReason, PendingColor},
+		{"init container waiting first", v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending,
+			InitContainerStatuses: []v1.ContainerStatus{waiting(CrashLoopBackOffReason)},
+			ContainerStatuses:     []v1.ContainerStatus{waiting(PodInitializingReason)}}}, CrashLoopBackOffReason, BackOffColor},
+		{"image pull", v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending,
+			ContainerStatuses: []v1.ContainerStatus{waiting(ImagePullBackOffReason)}}}, ImagePullBackOffReason, BackOffColor},
+		{"crash loop after OOM", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{
+				State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: CrashLoopBackOffReason}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: OOMKilledReason}},
+			}}}}, OOMKilledReason, BackOffColor},
+		{"OOM killed", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{
+				State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: OOMKilledReason}},
+			}}}}, OOMKilledReason, BackOffColor},
+		{"evicted", v1.Pod{Status: v1.PodStatus{Phase: v1.PodFailed, Reason: EvictedReason}}, EvictedReason, EvictedColor},
+		{"terminating", v1.Pod{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &deletionTimestamp},
+			Status: v1.PodStatus{Phase: v1.PodRunning}}, TerminatingReason, TerminatingColor},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := Pod{Delegate: test.pod}
+			if got := pod.Reason(); got != test.want {
+				t.Errorf("Reason() = %q, want %q", got, test.want)
+			}
+			if color, _ := pod.StatusColor(); color != test.wantColor {
+				t.Errorf("StatusColor() = %s, want %s", color, test.wantColor)
+			}
+		})
+	}
+}
+
+func TestPodLabel(t *testing.T) {
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1"}, Status: v1.PodStatus{Phase: v1.PodRunning,
+		ContainerStatuses: []v1.ContainerStatus{
+			{RestartCount: 3, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: CrashLoopBackOffReason}}},
+			{RestartCount: 4},
+		}}}
+	if got, want := (Pod{Delegate: pod}).Label(), "api-1 (CrashLoopBackOff, 7 restarts)"; got != want {
+		t.Errorf("Label() = %q, want %q", got, want)
+	}
+	pod.Status = v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{{RestartCount: 1}}}
+	if got, want := (Pod{Delegate: pod}).Label(), "api-1 (1 restart)"; got != want {
+		t.Errorf("Label() = %q, want %q", got, want)
+	}
+	pod.Status = v1.PodStatus{Phase: v1.PodPending,
+		InitContainerStatuses: []v1.ContainerStatus{
+			{RestartCount: 5, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: CrashLoopBackOffReason}}}},
+		ContainerStatuses: []v1.ContainerStatus{waiting(PodInitializingReason)}}
+	if got, want := (Pod{Delegate: pod}).Label(), "api-1 (CrashLoopBackOff, 5 restarts)"; got != want {
+		t.Errorf("Label() = %q, want %q", got, want)
+	}
+}
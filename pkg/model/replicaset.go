@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ReplicaSet struct {
+	Delegate v1.ReplicaSet
+}
+
+func (r ReplicaSet) Kind() string {
+	return "ReplicaSet"
+}
+func (r ReplicaSet) Id() string {
+	return fmt.Sprintf("rs %s", r.Delegate.Name)
+}
+func (r ReplicaSet) Name() string {
+	return r.Delegate.Name
+}
+func (r ReplicaSet) Label() string {
+	return r.Delegate.Name
+}
+func (r ReplicaSet) Labels() map[string]string {
+	return r.Delegate.Labels
+}
+func (r ReplicaSet) Icon() string {
+	return "images/deployment.png"
+}
+func (r ReplicaSet) StatusColor() (string, bool) {
+	return "", false
+}
+func (r ReplicaSet) OwnerReferences() []metav1.OwnerReference {
+	return r.Delegate.OwnerReferences
+}
+func (r ReplicaSet) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, v1.SchemeGroupVersion.WithKind(r.Kind()).GroupKind(), &r.Delegate)
+}
+func (r ReplicaSet) ConnectedKinds() []string {
+	return []string{}
+}
+func (r ReplicaSet) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
+
+// IsInactive returns true for the scaled down ReplicaSets, like the ones of the previous rollouts of a Deployment
+func (r ReplicaSet) IsInactive() bool {
+	return r.Delegate.Spec.Replicas != nil && *r.Delegate.Spec.Replicas == 0 && r.Delegate.Status.Replicas == 0
+}
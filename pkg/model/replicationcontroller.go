@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ReplicationController struct {
+	Delegate v1.ReplicationController
+}
+
+func (r ReplicationController) Kind() string {
+	return "ReplicationController"
+}
+func (r ReplicationController) Id() string {
+	return fmt.Sprintf("rc %s", r.Delegate.Name)
+}
+func (r ReplicationController) Name() string {
+	return r.Delegate.Name
+}
+func (r ReplicationController) Label() string {
+	return r.Delegate.Name
+}
+func (r ReplicationController) Labels() map[string]string {
+	return r.Delegate.Labels
+}
+func (r ReplicationController) Icon() string {
+	return "images/deployment.png"
+}
+func (r ReplicationController) StatusColor() (string, bool) {
+	return "", false
+}
+func (r ReplicationController) OwnerReferences() []metav1.OwnerReference {
+	return r.Delegate.OwnerReferences
+}
+func (r ReplicationController) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, v1.SchemeGroupVersion.WithKind(r.Kind()).GroupKind(), &r.Delegate)
+}
+func (r ReplicationController) ConnectedKinds() []string {
+	return []string{}
+}
+func (r ReplicationController) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
+
+// IsInactive returns true for the scaled down ReplicationControllers, like the ones of the previous rollouts of a DeploymentConfig
+func (r ReplicationController) IsInactive() bool {
+	return r.Delegate.Spec.Replicas != nil && *r.Delegate.Spec.Replicas == 0 && r.Delegate.Status.Replicas == 0
+}
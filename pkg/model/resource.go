@@ -1,7 +1,10 @@
 package model
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type Resource interface {
@@ -18,3 +21,17 @@ type Resource interface {
 	ConnectedKinds() []string
 	ConnectedResources(kind string, resources []Resource) ([]Resource, string)
 }
+
+// IsOwnedBy returns true if the owner reference points to the given object of the given kind.
+// The UIDs are compared when both are known, otherwise the group, kind and name, as for manifests exported without UIDs
+func IsOwnedBy(owner metav1.OwnerReference, kind schema.GroupKind, object metav1.Object) bool {
+	if owner.UID != "" && object.GetUID() != "" {
+		return owner.UID == object.GetUID()
+	}
+	groupVersion, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return false
+	}
+	return strings.Compare(groupVersion.Group, kind.Group) == 0 && strings.Compare(owner.Kind, kind.Kind) == 0 &&
+		strings.Compare(owner.Name, object.GetName()) == 0
+}
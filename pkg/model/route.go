@@ -24,6 +24,9 @@ func (r Route) Name() string {
 func (r Route) Label() string {
 	return r.Delegate.Name
 }
+func (r Route) Labels() map[string]string {
+	return r.Delegate.Labels
+}
 func (r Route) Icon() string {
 	return "images/ingress.png"
 }
@@ -43,7 +46,10 @@ func (r Route) ConnectedResources(kind string, resources []Resource) ([]Resource
 	connected := make([]Resource, 0)
 	if strings.Compare(r.Delegate.Spec.To.Kind, "Service") == 0 {
 		for _, resource := range resources {
-			service := resource.(Service)
+			service, ok := resource.(Service)
+			if !ok {
+				continue
+			}
 			serviceName := r.Delegate.Spec.To.Name
 			if strings.Compare(serviceName, service.Name()) == 0 {
 				connected = append(connected, service)
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Secret holds only the metadata of a Secret, never its data
+type Secret struct {
+	Delegate v1.Secret
+}
+
+// NewSecret copies the metadata of the given Secret, leaving out the data and the annotations that may contain it
+func NewSecret(secret v1.Secret) Secret {
+	return Secret{Delegate: v1.Secret{
+		TypeMeta: secret.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{Name: secret.Name, Namespace: secret.Namespace, UID: secret.UID,
+			Labels: secret.Labels, OwnerReferences: secret.OwnerReferences},
+		Type: secret.Type,
+	}}
+}
+
+func (s Secret) Kind() string {
+	return "Secret"
+}
+func (s Secret) Id() string {
+	return fmt.Sprintf("secret %s", s.Delegate.Name)
+}
+func (s Secret) Name() string {
+	return s.Delegate.Name
+}
+func (s Secret) Label() string {
+	return s.Delegate.Name
+}
+func (s Secret) Labels() map[string]string {
+	return s.Delegate.Labels
+}
+func (s Secret) Icon() string {
+	return "images/generic.png"
+}
+func (s Secret) StatusColor() (string, bool) {
+	return "", false
+}
+func (s Secret) OwnerReferences() []metav1.OwnerReference {
+	return s.Delegate.OwnerReferences
+}
+func (s Secret) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (s Secret) ConnectedKinds() []string {
+	return []string{}
+}
+func (s Secret) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
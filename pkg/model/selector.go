@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// MatchesLabelSelector evaluates the given LabelSelector, including its matchExpressions, against the labels of an object.
+// A nil selector matches nothing while an empty one matches everything, as for the selectors of the NetworkPolicies
+func MatchesLabelSelector(selector *metav1.LabelSelector, objectLabels map[string]string) bool {
+	if selector == nil {
+		return false
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		logger.Warnf("Invalid selector %s: %v", metav1.FormatLabelSelector(selector), err)
+		return false
+	}
+	return labelSelector.Matches(labels.Set(objectLabels))
+}
+
+// MatchesSelector evaluates an equality-based selector, like the one of a Service, against the labels of an object.
+// An empty selector matches nothing
+func MatchesSelector(selector map[string]string, objectLabels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	return labels.SelectorFromSet(selector).Matches(labels.Set(objectLabels))
+}
+
+// selectorOf converts either a map of labels or a LabelSelector with matchLabels and matchExpressions
+func selectorOf(value interface{}) (labels.Selector, error) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not a selector: %v", value)
+	}
+	_, hasMatchLabels := fields["matchLabels"]
+	_, hasMatchExpressions := fields["matchExpressions"]
+	if hasMatchLabels || hasMatchExpressions {
+		labelSelector := metav1.LabelSelector{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(fields, &labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		return metav1.LabelSelectorAsSelector(&labelSelector)
+	}
+
+	set := labels.Set{}
+	for key, value := range fields {
+		set[key] = fmt.Sprintf("%v", value)
+	}
+	return labels.SelectorFromSet(set), nil
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestMatchesLabelSelector(t *testing.T) {
+	podLabels := map[string]string{"app": "api", "tier": "backend"}
+	tests := []struct {
+		name     string
+		selector *metav1.LabelSelector
+		want     bool
+	}{
+		{"nil selector matches nothing", nil, false},
+		{"empty selector matches everything", &metav1.LabelSelector{}, true},
+		{"matching labels", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}, true},
+		{"other labels", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, false},
+		{"In expression", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend", "backend"}}}}, true},
+		{"NotIn expression", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"backend"}}}}, false},
+		{"Exists expression", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: metav1.LabelSelectorOpExists}}}, true},
+		{"DoesNotExist expression", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: metav1.LabelSelectorOpDoesNotExist}}}, false},
+		{"labels and expressions", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend"}}}}, false},
+		{"invalid operator", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: "Like", Values: []string{"api"}}}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := MatchesLabelSelector(test.selector, podLabels); got != test.want {
+				t.Errorf("MatchesLabelSelector() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMatchesSelector(t *testing.T) {
+	podLabels := map[string]string{"app": "api", "tier": "backend"}
+	tests := []struct {
+		name     string
+		selector map[string]string
+		want     bool
+	}{
+		{"empty selector matches nothing", map[string]string{}, false},
+		{"subset of the labels", map[string]string{"app": "api"}, true},
+		{"all the labels", map[string]string{"app": "api", "tier": "backend"}, true},
+		{"other value", map[string]string{"app": "web"}, false},
+		{"missing label", map[string]string{"app": "api", "version": "v1"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := MatchesSelector(test.selector, podLabels); got != test.want {
+				t.Errorf("MatchesSelector() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSelectorOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{"map of labels", map[string]interface{}{"app": "api"}, "app=api", false},
+		{"non string values", map[string]interface{}{"replicas": int64(3)}, "replicas=3", false},
+		{"matchLabels", map[string]interface{}{"matchLabels": map[string]interface{}{"app": "api"}}, "app=api", false},
+		{"matchExpressions", map[string]interface{}{"matchExpressions": []interface{}{
+			map[string]interface{}{"key": "tier", "operator": "In", "values": []interface{}{"backend"}}}}, "tier in (backend)", false},
+		{"not a map", "app=api", "", true},
+		{"invalid operator", map[string]interface{}{"matchExpressions": []interface{}{
+			map[string]interface{}{"key": "tier", "operator": "Like"}}}, "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selector, err := selectorOf(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("selectorOf() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			want, err := labels.Parse(test.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if selector.String() != want.String() {
+				t.Errorf("selectorOf() = %s, want %s", selector, want)
+			}
+		})
+	}
+}
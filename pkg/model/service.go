@@ -10,6 +10,14 @@ import (
 
 type Service struct {
 	Delegate v1.Service
+	// Backends are the Pods found in the Endpoints or EndpointSlices of the Service, nil when not collected
+	Backends []Backend
+}
+
+// Backend is a Pod serving the traffic of a Service
+type Backend struct {
+	PodName string
+	Ready   bool
 }
 
 func IsKNativeSkippableService(service v1.Service) bool {
@@ -30,14 +38,36 @@ func (s Service) Name() string {
 	return s.Delegate.Name
 }
 func (s Service) Label() string {
+	if s.hasNoReadyEndpoints() {
+		return fmt.Sprintf("%s (no ready endpoints)", s.Delegate.Name)
+	}
 	return s.Delegate.Name
 }
+func (s Service) Labels() map[string]string {
+	return s.Delegate.Labels
+}
 func (s Service) Icon() string {
 	return "images/svc.png"
 }
 func (s Service) StatusColor() (string, bool) {
+	if s.hasNoReadyEndpoints() {
+		return FailedColor, true
+	}
 	return "", false
 }
+
+// hasNoReadyEndpoints returns true if the endpoints were collected and none of them is ready
+func (s Service) hasNoReadyEndpoints() bool {
+	if s.Backends == nil || s.Delegate.Spec.Type == v1.ServiceTypeExternalName {
+		return false
+	}
+	for _, backend := range s.Backends {
+		if backend.Ready {
+			return false
+		}
+	}
+	return true
+}
 func (s Service) OwnerReferences() []metav1.OwnerReference {
 	return s.Delegate.OwnerReferences
 }
@@ -50,29 +80,37 @@ func (s Service) ConnectedKinds() []string {
 func (s Service) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
 	connected := make([]Resource, 0)
 	for _, resource := range resources {
-		pod := resource.(Pod)
-		if s.matchSelector(pod) {
+		pod, ok := resource.(Pod)
+		if !ok {
+			continue
+		}
+		if s.Backends != nil {
+			if _, ok := s.backendOf(pod); ok {
+				connected = append(connected, pod)
+			}
+		} else if MatchesSelector(s.Delegate.Spec.Selector, pod.Labels()) {
 			connected = append(connected, pod)
 		}
 	}
 
 	return connected, ""
 }
-func (s Service) matchSelector(pod Pod) bool {
-	for label, value := range s.Delegate.Spec.Selector {
-		found := false
-		for podLabel, podValue := range pod.Delegate.ObjectMeta.Labels {
-			if strings.Compare(podLabel, label) == 0 {
-				if strings.Compare(podValue, value) != 0 {
-					return false
-				}
-				found = true
-			}
-		}
-		if !found {
-			return false
+
+// ConnectionName marks the Pods that are not ready to serve the traffic
+func (s Service) ConnectionName(to Resource) string {
+	if pod, ok := to.(Pod); ok {
+		if backend, ok := s.backendOf(pod); ok && !backend.Ready {
+			return "not ready"
 		}
 	}
+	return ""
+}
 
-	return len(s.Delegate.Spec.Selector) > 0
+func (s Service) backendOf(pod Pod) (Backend, bool) {
+	for _, backend := range s.Backends {
+		if strings.Compare(backend.PodName, pod.Name()) == 0 {
+			return backend, true
+		}
+	}
+	return Backend{}, false
 }
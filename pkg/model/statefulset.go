@@ -2,7 +2,6 @@ package model
 
 import (
 	"fmt"
-	"strings"
 
 	v1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,21 +23,52 @@ func (s StatefulSet) Name() string {
 func (s StatefulSet) Label() string {
 	return s.Delegate.Name
 }
+func (s StatefulSet) Labels() map[string]string {
+	return s.Delegate.Labels
+}
 func (s StatefulSet) Icon() string {
 	return "images/sts.png"
 }
 func (s StatefulSet) StatusColor() (string, bool) {
-	return "", false
+	// The rolling update is in progress until all the replicas run the update revision
+	if s.Delegate.Status.ObservedGeneration > 0 && s.Delegate.Status.UpdateRevision != "" &&
+		s.Delegate.Status.CurrentRevision != s.Delegate.Status.UpdateRevision {
+		return ProgressingColor, true
+	}
+	return rolloutStatusColor(s.Delegate.Status.ObservedGeneration, desiredReplicas(s.Delegate.Spec.Replicas),
+		s.Delegate.Status.ReadyReplicas, s.Delegate.Status.UpdatedReplicas, []Condition{})
 }
 func (s StatefulSet) OwnerReferences() []metav1.OwnerReference {
 	return s.Delegate.OwnerReferences
 }
 func (s StatefulSet) IsOwnerOf(owner metav1.OwnerReference) bool {
-	return strings.Compare(owner.Kind, s.Kind()) == 0 && strings.Compare(owner.Name, s.Name()) == 0
+	return IsOwnedBy(owner, v1.SchemeGroupVersion.WithKind(s.Kind()).GroupKind(), &s.Delegate)
 }
 func (s StatefulSet) ConnectedKinds() []string {
-	return []string{}
+	return []string{"ConfigMap", "Secret", "PersistentVolumeClaim", DanglingReferenceKind}
 }
 func (s StatefulSet) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
-	return []Resource{}, ""
+	if kind == "PersistentVolumeClaim" {
+		connected := make([]Resource, 0)
+		for _, resource := range resources {
+			claim, ok := resource.(PersistentVolumeClaim)
+			if !ok {
+				continue
+			}
+			for _, template := range s.Delegate.Spec.VolumeClaimTemplates {
+				if claim.IsGeneratedFrom(s.Name(), template.Name) {
+					connected = append(connected, claim)
+					break
+				}
+			}
+		}
+		return connected, "volumeClaimTemplate"
+	}
+	return connectedConfigResources(s.ConfigReferences(), resources), ""
+}
+func (s StatefulSet) ConfigReferences() []ConfigReference {
+	return ConfigReferencesOf(s.Delegate.Spec.Template.Spec)
+}
+func (s StatefulSet) ConnectionName(to Resource) string {
+	return configConnectionName(s.ConfigReferences(), to)
 }
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"strings"
+)
+
+// Condition is the common shape of the status conditions of the different APIs
+type Condition struct {
+	Type   string
+	Status string
+	Reason string
+}
+
+// ConditionsStatusColor derives the status from the Ready, or else Available, condition: True is running, False is
+// failed and Unknown, as set while reconciling, is progressing
+func ConditionsStatusColor(conditions []Condition) (string, bool) {
+	condition, ok := conditionOf(conditions, "Ready")
+	if !ok {
+		condition, ok = conditionOf(conditions, "Available")
+	}
+	if !ok {
+		return "", false
+	}
+	switch condition.Status {
+	case "True":
+		return RunningColor, true
+	case "False":
+		return FailedColor, true
+	}
+	return ProgressingColor, true
+}
+
+// rolloutStatusColor derives the status of a workload from its replicas: progressing while the replicas are updated or
+// none of them is available yet, like when the workload starts or scales up, then running when all the desired replicas
+// are available and degraded when some of them are. It is failed only when the rollout exceeded its deadline.
+// There is no status until the controller has observed the workload
+func rolloutStatusColor(observedGeneration int64, desired int32, available int32, updated int32,
+	conditions []Condition) (string, bool) {
+	if observedGeneration == 0 {
+		return "", false
+	}
+	if condition, ok := conditionOf(conditions, "Progressing"); ok && condition.Status == "False" {
+		return FailedColor, true
+	}
+	switch {
+	case desired == 0:
+		return "", false
+	case updated < desired:
+		return ProgressingColor, true
+	case available >= desired:
+		return RunningColor, true
+	case available == 0:
+		return ProgressingColor, true
+	}
+	return WarningColor, true
+}
+
+// desiredReplicas returns the number of replicas of the spec, 1 when not set
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func conditionOf(conditions []Condition, conditionType string) (Condition, bool) {
+	for _, condition := range conditions {
+		if strings.Compare(condition.Type, conditionType) == 0 {
+			return condition, true
+		}
+	}
+	return Condition{}, false
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestRolloutStatusColor(t *testing.T) {
+	deadlineExceeded := []Condition{{Type: "Progressing", Status: "False", Reason: "ProgressDeadlineExceeded"}}
+	tests := []struct {
+		name               string
+		observedGeneration int64
+		desired            int32
+		available          int32
+		updated            int32
+		conditions         []Condition
+		want               string
+	}{
+		{"not observed", 0, 2, 0, 0, nil, ""},
+		{"scaled to zero", 1, 0, 0, 0, nil, ""},
+		{"updating", 2, 2, 2, 1, nil, ProgressingColor},
+		{"starting", 1, 2, 0, 2, nil, ProgressingColor},
+		{"degraded", 1, 3, 1, 3, nil, WarningColor},
+		{"running", 1, 2, 2, 2, nil, RunningColor},
+		{"deadline exceeded", 2, 2, 0, 2, deadlineExceeded, FailedColor},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			color, _ := rolloutStatusColor(test.observedGeneration, test.desired, test.available, test.updated, test.conditions)
+			if color != test.want {
+				t.Errorf("rolloutStatusColor() = %q, want %q", color, test.want)
+			}
+		})
+	}
+}
+
+func TestStatefulSetStatusColor(t *testing.T) {
+	replicas := int32(2)
+	tests := []struct {
+		name   string
+		status appsv1.StatefulSetStatus
+		want   string
+	}{
+		{"rolling update", appsv1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 2, UpdatedReplicas: 2,
+			CurrentRevision: "db-1", UpdateRevision: "db-2"}, ProgressingColor},
+		{"updated", appsv1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 2, UpdatedReplicas: 2,
+			CurrentRevision: "db-2", UpdateRevision: "db-2"}, RunningColor},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statefulSet := appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: &replicas}, Status: test.status}
+			if color, _ := (StatefulSet{Delegate: statefulSet}).StatusColor(); color != test.want {
+				t.Errorf("StatusColor() = %q, want %q", color, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+type StorageClass struct {
+	Delegate v1.StorageClass
+}
+
+func (s StorageClass) Kind() string {
+	return "StorageClass"
+}
+func (s StorageClass) Id() string {
+	return fmt.Sprintf("sc %s", s.Delegate.Name)
+}
+func (s StorageClass) Name() string {
+	return s.Delegate.Name
+}
+func (s StorageClass) Label() string {
+	if s.Delegate.Annotations[defaultStorageClassAnnotation] == "true" {
+		return fmt.Sprintf("%s (default)", s.Delegate.Name)
+	}
+	return s.Delegate.Name
+}
+func (s StorageClass) Labels() map[string]string {
+	return s.Delegate.Labels
+}
+func (s StorageClass) Icon() string {
+	return "images/generic.png"
+}
+func (s StorageClass) StatusColor() (string, bool) {
+	return "", false
+}
+func (s StorageClass) OwnerReferences() []metav1.OwnerReference {
+	return s.Delegate.OwnerReferences
+}
+func (s StorageClass) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return false
+}
+func (s StorageClass) ConnectedKinds() []string {
+	return []string{}
+}
+func (s StorageClass) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	return []Resource{}, ""
+}
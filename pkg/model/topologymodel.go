@@ -1,27 +1,65 @@
 package model
 
+import "sync"
+
+// TopologyModel holds the namespace models, it is safe for concurrent use
 type TopologyModel struct {
+	lock             sync.RWMutex
+	namespaceNames   []string
 	namespacesByName map[string]*NamespaceModel
+	clusterScoped    *NamespaceModel
+	warnings         []string
 }
 
 func NewTopologyModel() *TopologyModel {
 	var topology TopologyModel
 	topology.namespacesByName = make(map[string]*NamespaceModel)
+	topology.clusterScoped = &NamespaceModel{resourcesByKind: make(map[string][]Resource), events: make(Events)}
 	return &topology
 }
 
-func (topology TopologyModel) AddNamespace(name string) *NamespaceModel {
-	namespace := NamespaceModel{name: name, resourcesByKind: make(map[string][]Resource)}
+func (topology *TopologyModel) AddNamespace(name string) *NamespaceModel {
+	topology.lock.Lock()
+	defer topology.lock.Unlock()
+	namespace := NamespaceModel{name: name, resourcesByKind: make(map[string][]Resource), events: make(Events)}
+	if _, ok := topology.namespacesByName[name]; !ok {
+		topology.namespaceNames = append(topology.namespaceNames, name)
+	}
 	topology.namespacesByName[name] = &namespace
 	return &namespace
 }
-func (topology TopologyModel) NamespaceByName(name string) *NamespaceModel {
+func (topology *TopologyModel) NamespaceByName(name string) *NamespaceModel {
+	topology.lock.RLock()
+	defer topology.lock.RUnlock()
 	return topology.namespacesByName[name]
 }
-func (topology TopologyModel) AllNamespaces() []NamespaceModel {
-	namespaces := make([]NamespaceModel, 0, len(topology.namespacesByName))
-	for _, namespace := range topology.namespacesByName {
-		namespaces = append(namespaces, *namespace)
+
+// AllNamespaces returns the namespaces in the order they were added
+func (topology *TopologyModel) AllNamespaces() []*NamespaceModel {
+	topology.lock.RLock()
+	defer topology.lock.RUnlock()
+	namespaces := make([]*NamespaceModel, 0, len(topology.namespaceNames))
+	for _, name := range topology.namespaceNames {
+		namespaces = append(namespaces, topology.namespacesByName[name])
 	}
 	return namespaces
 }
+
+// ClusterScoped holds the cluster-scoped resources shared by the namespaces, like the PersistentVolumes,
+// and their connections with the namespaced resources
+func (topology *TopologyModel) ClusterScoped() *NamespaceModel {
+	return topology.clusterScoped
+}
+
+// AddWarning records a problem that occurred while collecting the cluster-scoped resources
+func (topology *TopologyModel) AddWarning(warning string) {
+	topology.lock.Lock()
+	defer topology.lock.Unlock()
+	topology.warnings = append(topology.warnings, warning)
+}
+
+func (topology *TopologyModel) Warnings() []string {
+	topology.lock.RLock()
+	defer topology.lock.RUnlock()
+	return append([]string{}, topology.warnings...)
+}
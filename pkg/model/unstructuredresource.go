@@ -0,0 +1,209 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Relation defines how an UnstructuredResource is connected to the resources of another kind
+type Relation struct {
+	// Name of the connection
+	Name string
+	// Kind of the connected resources, as returned by Resource.Kind, like Service or example.com/Widget
+	Kind string
+	// JSONPath of the field holding the name(s) of the connected resources, like .spec.serviceName
+	Field string
+	// JSONPath of the label selector matching the connected resources, like .spec.selector
+	Selector string
+}
+
+// Validate checks the syntax of the JSONPath expressions
+func (r Relation) Validate() error {
+	if r.Kind == "" {
+		return fmt.Errorf("missing kind in relation %s", r.Name)
+	}
+	if (r.Field == "") == (r.Selector == "") {
+		return fmt.Errorf("exactly one of field or selector is required in relation %s", r.Name)
+	}
+	for _, path := range []string{r.Field, r.Selector} {
+		if path != "" {
+			if err := jsonpath.New(r.Name).Parse(jsonPathTemplate(path)); err != nil {
+				return fmt.Errorf("invalid path %s in relation %s: %v", path, r.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// QualifiedKind returns the kind prefixed by its API group, like example.com/Widget, so that the kinds collected with the
+// dynamic client never share the kind of the typed resources, like Service
+func QualifiedKind(groupKind schema.GroupKind) string {
+	group := groupKind.Group
+	if group == "" {
+		group = "core"
+	}
+	return fmt.Sprintf("%s/%s", group, groupKind.Kind)
+}
+
+// Labeled is implemented by the resources exposing the labels of the underlying object
+type Labeled interface {
+	Labels() map[string]string
+}
+
+// UnstructuredResource models any resource collected with the dynamic client, like the instances of a CRD
+type UnstructuredResource struct {
+	Delegate  unstructured.Unstructured
+	IconPath  string
+	Relations []Relation
+}
+
+func (u UnstructuredResource) Kind() string {
+	return QualifiedKind(u.Delegate.GroupVersionKind().GroupKind())
+}
+func (u UnstructuredResource) Id() string {
+	return fmt.Sprintf("%s %s", strings.ToLower(u.Kind()), u.Delegate.GetName())
+}
+func (u UnstructuredResource) Name() string {
+	return u.Delegate.GetName()
+}
+func (u UnstructuredResource) Label() string {
+	return u.Delegate.GetName()
+}
+func (u UnstructuredResource) Labels() map[string]string {
+	return u.Delegate.GetLabels()
+}
+func (u UnstructuredResource) Icon() string {
+	if u.IconPath != "" {
+		return u.IconPath
+	}
+	return "images/crd.png"
+}
+func (u UnstructuredResource) StatusColor() (string, bool) {
+	conditions, found, err := unstructured.NestedSlice(u.Delegate.Object, "status", "conditions")
+	if err != nil || !found {
+		return "", false
+	}
+	statusConditions := make([]Condition, 0)
+	for _, value := range conditions {
+		condition, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _ := condition["type"].(string)
+		status, _ := condition["status"].(string)
+		reason, _ := condition["reason"].(string)
+		statusConditions = append(statusConditions, Condition{Type: conditionType, Status: status, Reason: reason})
+	}
+	return ConditionsStatusColor(statusConditions)
+}
+func (u UnstructuredResource) OwnerReferences() []metav1.OwnerReference {
+	return u.Delegate.GetOwnerReferences()
+}
+func (u UnstructuredResource) IsOwnerOf(owner metav1.OwnerReference) bool {
+	return IsOwnedBy(owner, u.Delegate.GroupVersionKind().GroupKind(), &u.Delegate)
+}
+func (u UnstructuredResource) ConnectedKinds() []string {
+	kinds := make([]string, 0)
+	for _, relation := range u.Relations {
+		if !containsString(kinds, relation.Kind) {
+			kinds = append(kinds, relation.Kind)
+		}
+	}
+	return kinds
+}
+func (u UnstructuredResource) ConnectedResources(kind string, resources []Resource) ([]Resource, string) {
+	connected := make([]Resource, 0)
+	names := make([]string, 0)
+	for _, relation := range u.Relations {
+		if strings.Compare(relation.Kind, kind) != 0 {
+			continue
+		}
+		matched := false
+		for _, resource := range resources {
+			if u.isRelated(relation, resource) {
+				connected = append(connected, resource)
+				matched = true
+			}
+		}
+		if matched && relation.Name != "" {
+			names = append(names, relation.Name)
+		}
+	}
+	return connected, strings.Join(names, ", ")
+}
+
+func (u UnstructuredResource) isRelated(relation Relation, resource Resource) bool {
+	if relation.Field != "" {
+		for _, value := range u.fieldValues(relation.Field) {
+			if name, ok := value.(string); ok && strings.Compare(name, resource.Name()) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	labeled, ok := resource.(Labeled)
+	if !ok {
+		return false
+	}
+	for _, value := range u.fieldValues(relation.Selector) {
+		selector, err := selectorOf(value)
+		if err != nil {
+			logger.Warnf("Invalid selector %s in %s: %v", relation.Selector, u.Id(), err)
+			continue
+		}
+		if !selector.Empty() && selector.Matches(labels.Set(labeled.Labels())) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldValues returns the values found at the given path, flattening the arrays
+func (u UnstructuredResource) fieldValues(path string) []interface{} {
+	parser := jsonpath.New(u.Id()).AllowMissingKeys(true)
+	err := parser.Parse(jsonPathTemplate(path))
+	if err != nil {
+		logger.Warnf("Invalid path %s in %s: %v", path, u.Id(), err)
+		return nil
+	}
+	results, err := parser.FindResults(u.Delegate.Object)
+	if err != nil {
+		logger.Debugf("Cannot evaluate path %s in %s: %v", path, u.Id(), err)
+		return nil
+	}
+	values := make([]interface{}, 0)
+	for _, result := range results {
+		for _, value := range result {
+			if array, ok := value.Interface().([]interface{}); ok {
+				values = append(values, array...)
+			} else {
+				values = append(values, value.Interface())
+			}
+		}
+	}
+	return values
+}
+
+func jsonPathTemplate(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	return fmt.Sprintf("{%s}", path)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if strings.Compare(v, value) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// HighUtilization is the ratio of the requests above which the utilization is high
+const HighUtilization = 0.8
+
+// Measured is implemented by the resources whose CPU and memory usage was collected
+type Measured interface {
+	Utilization() (Utilization, bool)
+}
+
+// Utilization compares the CPU and memory used by the containers with their requests and limits
+type Utilization struct {
+	CPU    Consumption
+	Memory Consumption
+}
+
+// Consumption is the usage of a compute resource, like the CPU, and the sum of the requests and limits of the containers
+type Consumption struct {
+	Usage   resource.Quantity
+	Request resource.Quantity
+	Limit   resource.Quantity
+}
+
+// NewUtilization returns the given usage of a Pod compared with the requests and limits of its containers
+func NewUtilization(spec v1.PodSpec, usage v1.ResourceList) Utilization {
+	utilization := Utilization{}
+	utilization.CPU.Usage = usage.Cpu().DeepCopy()
+	utilization.Memory.Usage = usage.Memory().DeepCopy()
+	for _, container := range spec.Containers {
+		utilization.CPU.Request.Add(*container.Resources.Requests.Cpu())
+		utilization.CPU.Limit.Add(*container.Resources.Limits.Cpu())
+		utilization.Memory.Request.Add(*container.Resources.Requests.Memory())
+		utilization.Memory.Limit.Add(*container.Resources.Limits.Memory())
+	}
+	return utilization
+}
+
+// Add sums the given utilization, like the one of another Pod of the same workload
+func (u *Utilization) Add(other Utilization) {
+	u.CPU.add(other.CPU)
+	u.Memory.add(other.Memory)
+}
+
+// addUtilization returns the sum of the given utilizations, the first one being nil when not yet known
+func addUtilization(sum *Utilization, utilization Utilization) *Utilization {
+	if sum == nil {
+		sum = &Utilization{}
+	}
+	sum.Add(utilization)
+	return sum
+}
+
+func (c *Consumption) add(other Consumption) {
+	c.Usage.Add(other.Usage)
+	c.Request.Add(other.Request)
+	c.Limit.Add(other.Limit)
+}
+
+// String returns the usage compared with the requests, like cpu 120m/250m, memory 200Mi/256Mi
+func (u Utilization) String() string {
+	return strings.Join([]string{u.CPU.format(v1.ResourceCPU), u.Memory.format(v1.ResourceMemory)}, ", ")
+}
+
+func (c Consumption) format(name v1.ResourceName) string {
+	if c.Request.IsZero() {
+		return fmt.Sprintf("%s %s", name, formatQuantity(name, c.Usage))
+	}
+	return fmt.Sprintf("%s %s/%s", name, formatQuantity(name, c.Usage), formatQuantity(name, c.Request))
+}
+
+// formatQuantity returns the CPU in millicores and the memory in mebibytes, as the usage is not rounded
+func formatQuantity(name v1.ResourceName, quantity resource.Quantity) string {
+	if name == v1.ResourceCPU {
+		return fmt.Sprintf("%dm", quantity.MilliValue())
+	}
+	return fmt.Sprintf("%dMi", quantity.Value()/(1024*1024))
+}
+
+// Ratio returns the usage divided by the requests, if any
+func (c Consumption) Ratio() (float64, bool) {
+	if c.Request.IsZero() {
+		return 0, false
+	}
+	return c.Usage.AsApproximateFloat64() / c.Request.AsApproximateFloat64(), true
+}
+
+// Color returns the color of the highest ratio of the CPU and memory requests in use
+func (u Utilization) Color() (string, bool) {
+	cpuRatio, hasCPURequest := u.CPU.Ratio()
+	memoryRatio, hasMemoryRequest := u.Memory.Ratio()
+	if !hasCPURequest && !hasMemoryRequest {
+		return "", false
+	}
+	ratio := cpuRatio
+	if memoryRatio > ratio {
+		ratio = memoryRatio
+	}
+	switch {
+	case ratio > 1:
+		return FailedColor, true
+	case ratio > HighUtilization:
+		return WarningColor, true
+	}
+	return RunningColor, true
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"fmt"
+)
+
+// Workload is a controller, like a Deployment, aggregating the Pods it runs when the Pods are not drawn
+type Workload struct {
+	Resource
+	// Ready is the number of running and ready, or completed, Pods
+	Ready int
+	Total int
+	// Usage is the sum of the CPU and memory usage of the Pods, nil when the metrics were not collected
+	Usage *Utilization
+}
+
+func NewWorkload(resource Resource, pods []Pod) Workload {
+	workload := Workload{Resource: resource, Total: len(pods)}
+	for _, pod := range pods {
+		if color, _ := pod.StatusColor(); color == RunningColor || color == CompletedColor {
+			workload.Ready++
+		}
+		if usage, ok := pod.Utilization(); ok {
+			workload.Usage = addUtilization(workload.Usage, usage)
+		}
+	}
+	return workload
+}
+
+func (w Workload) Label() string {
+	if w.Usage != nil {
+		return fmt.Sprintf("%s (%d/%d, %s)", w.Resource.Label(), w.Ready, w.Total, w.Usage)
+	}
+	return fmt.Sprintf("%s (%d/%d)", w.Resource.Label(), w.Ready, w.Total)
+}
+func (w Workload) Utilization() (Utilization, bool) {
+	if w.Usage == nil {
+		return Utilization{}, false
+	}
+	return *w.Usage, true
+}
+func (w Workload) Labels() map[string]string {
+	if labeled, ok := w.Resource.(Labeled); ok {
+		return labeled.Labels()
+	}
+	return map[string]string{}
+}
+
+// StatusColor returns the status of the controller if any, otherwise the status of its Pods
+func (w Workload) StatusColor() (string, bool) {
+	if color, ok := w.Resource.StatusColor(); ok {
+		return color, ok
+	}
+	return podsStatusColor(w.Ready, w.Total)
+}
+
+func podsStatusColor(ready int, total int) (string, bool) {
+	switch {
+	case total == 0:
+		return "", false
+	case ready == total:
+		return RunningColor, true
+	case ready == 0:
+		return FailedColor, true
+	}
+	return WarningColor, true
+}
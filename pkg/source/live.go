@@ -0,0 +1,339 @@
+package source
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "github.com/openshift/client-go/apps/clientset/versioned/typed/apps/v1"
+	authv1 "github.com/openshift/client-go/authorization/clientset/versioned/typed/authorization/v1"
+	buildv1 "github.com/openshift/client-go/build/clientset/versioned/typed/build/v1"
+	imagev1 "github.com/openshift/client-go/image/clientset/versioned/typed/image/v1"
+	projectv1 "github.com/openshift/client-go/project/clientset/versioned/typed/project/v1"
+	routev1 "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	k8appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	discoveryv1client "k8s.io/client-go/kubernetes/typed/discovery/v1"
+	networkingv1client "k8s.io/client-go/kubernetes/typed/networking/v1"
+	storagev1client "k8s.io/client-go/kubernetes/typed/storage/v1"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	eventingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/eventing/v1"
+	flowsv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/flows/v1"
+	messagingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/messaging/v1"
+	sourcesv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/sources/v1"
+	servingv1 "knative.dev/serving/pkg/client/clientset/versioned/typed/serving/v1"
+)
+
+type lister func(namespace string, options metav1.ListOptions) (runtime.Object, error)
+
+// LiveSource lists the resources from a running cluster, using the typed clientsets.
+// Other kinds, like custom resources, are listed as Unstructured objects with the dynamic client
+type LiveSource struct {
+	listers       map[schema.GroupVersionKind]lister
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+}
+
+func NewLiveSource(config *rest.Config) (*LiveSource, error) {
+	routeClient, err := routev1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	appsClient, err := appsv1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	appsV1Client, err := k8appsv1client.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	coreClient, err := corev1client.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	batchClient, err := batchv1client.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	storageClient, err := storagev1client.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	networkingClient, err := networkingv1client.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	discoveryV1Client, err := discoveryv1client.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	authClient, err := authv1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	eventingClient, err := eventingv1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	servingClient, err := servingv1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	sourcesClient, err := sourcesv1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	messagingClient, err := messagingv1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	flowsClient, err := flowsv1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	imageClient, err := imagev1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	buildClient, err := buildv1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	projectClient, err := projectv1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataClient, err := metadata.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	source := LiveSource{dynamicClient: dynamicClient}
+	source.mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	source.listers = map[schema.GroupVersionKind]lister{
+		RouteKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return routeClient.Routes(namespace).List(context.TODO(), options)
+		},
+		IngressKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return networkingClient.Ingresses(namespace).List(context.TODO(), options)
+		},
+		IngressClassKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return networkingClient.IngressClasses().List(context.TODO(), options)
+		},
+		NetworkPolicyKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return networkingClient.NetworkPolicies(namespace).List(context.TODO(), options)
+		},
+		EndpointsKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.Endpoints(namespace).List(context.TODO(), options)
+		},
+		EndpointSliceKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return discoveryV1Client.EndpointSlices(namespace).List(context.TODO(), options)
+		},
+		ServiceKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.Services(namespace).List(context.TODO(), options)
+		},
+		DeploymentKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return appsV1Client.Deployments(namespace).List(context.TODO(), options)
+		},
+		StatefulSetKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return appsV1Client.StatefulSets(namespace).List(context.TODO(), options)
+		},
+		DeploymentConfigKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return appsClient.DeploymentConfigs(namespace).List(context.TODO(), options)
+		},
+		DaemonSetKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return appsV1Client.DaemonSets(namespace).List(context.TODO(), options)
+		},
+		JobKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return batchClient.Jobs(namespace).List(context.TODO(), options)
+		},
+		CronJobKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return batchClient.CronJobs(namespace).List(context.TODO(), options)
+		},
+		ReplicaSetKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return appsV1Client.ReplicaSets(namespace).List(context.TODO(), options)
+		},
+		ReplicationControllerKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.ReplicationControllers(namespace).List(context.TODO(), options)
+		},
+		PodKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.Pods(namespace).List(context.TODO(), options)
+		},
+		EventKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.Events(namespace).List(context.TODO(), options)
+		},
+		ConfigMapKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.ConfigMaps(namespace).List(context.TODO(), options)
+		},
+		SecretKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			// Only the metadata of the Secrets is fetched, never their data
+			list, err := metadataClient.Resource(corev1.SchemeGroupVersion.WithResource("secrets")).Namespace(namespace).
+				List(context.TODO(), options)
+			if err != nil {
+				return nil, err
+			}
+			secrets := &corev1.SecretList{ListMeta: list.ListMeta}
+			for _, item := range list.Items {
+				secrets.Items = append(secrets.Items, corev1.Secret{ObjectMeta: item.ObjectMeta})
+			}
+			return secrets, nil
+		},
+		PersistentVolumeClaimKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.PersistentVolumeClaims(namespace).List(context.TODO(), options)
+		},
+		PersistentVolumeKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.PersistentVolumes().List(context.TODO(), options)
+		},
+		StorageClassKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return storageClient.StorageClasses().List(context.TODO(), options)
+		},
+		ServiceAccountKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.ServiceAccounts(namespace).List(context.TODO(), options)
+		},
+		RoleBindingKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return authClient.RoleBindings(namespace).List(context.TODO(), options)
+		},
+		ClusterRoleBindingKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return authClient.ClusterRoleBindings().List(context.TODO(), options)
+		},
+		KnativeServiceKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return servingClient.Services(namespace).List(context.TODO(), options)
+		},
+		ConfigurationKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return servingClient.Configurations(namespace).List(context.TODO(), options)
+		},
+		RevisionKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return servingClient.Revisions(namespace).List(context.TODO(), options)
+		},
+		KnativeRouteKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return servingClient.Routes(namespace).List(context.TODO(), options)
+		},
+		SinkBindingKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return sourcesClient.SinkBindings(namespace).List(context.TODO(), options)
+		},
+		PingSourceKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return sourcesClient.PingSources(namespace).List(context.TODO(), options)
+		},
+		ApiServerSourceKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return sourcesClient.ApiServerSources(namespace).List(context.TODO(), options)
+		},
+		ContainerSourceKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return sourcesClient.ContainerSources(namespace).List(context.TODO(), options)
+		},
+		BrokerKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return eventingClient.Brokers(namespace).List(context.TODO(), options)
+		},
+		TriggerKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return eventingClient.Triggers(namespace).List(context.TODO(), options)
+		},
+		ChannelKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return messagingClient.Channels(namespace).List(context.TODO(), options)
+		},
+		InMemoryChannelKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return messagingClient.InMemoryChannels(namespace).List(context.TODO(), options)
+		},
+		SubscriptionKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return messagingClient.Subscriptions(namespace).List(context.TODO(), options)
+		},
+		SequenceKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return flowsClient.Sequences(namespace).List(context.TODO(), options)
+		},
+		ParallelKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return flowsClient.Parallels(namespace).List(context.TODO(), options)
+		},
+		NamespaceKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return coreClient.Namespaces().List(context.TODO(), options)
+		},
+		ImageStreamKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return imageClient.ImageStreams(namespace).List(context.TODO(), options)
+		},
+		BuildConfigKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return buildClient.BuildConfigs(namespace).List(context.TODO(), options)
+		},
+		BuildKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return buildClient.Builds(namespace).List(context.TODO(), options)
+		},
+		ProjectKind: func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return projectClient.Projects().List(context.TODO(), options)
+		},
+	}
+	return &source, nil
+}
+
+func (source *LiveSource) List(kind schema.GroupVersionKind, namespace string) ([]runtime.Object, error) {
+	lister, clusterScoped, err := source.lister(kind)
+	if err != nil {
+		return nil, err
+	}
+	if clusterScoped && namespace != "" {
+		return nil, fmt.Errorf("%s is cluster-scoped", kind.GroupKind())
+	}
+	list, err := lister(namespace, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return meta.ExtractList(list)
+}
+
+func (source *LiveSource) Get(kind schema.GroupVersionKind, namespace string, name string) (runtime.Object, error) {
+	lister, clusterScoped, err := source.lister(kind)
+	if err != nil {
+		return nil, err
+	}
+	if clusterScoped {
+		namespace = ""
+	}
+	list, err := lister(namespace, metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String()})
+	if err != nil {
+		return nil, err
+	}
+	objects, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, notFound(kind, name)
+	}
+	return objects[0], nil
+}
+
+// lister returns the typed lister of the given kind, or a dynamic one for the kinds without a typed clientset
+func (source *LiveSource) lister(kind schema.GroupVersionKind) (lister, bool, error) {
+	if lister, ok := source.listers[kind]; ok {
+		return lister, kind == ClusterRoleBindingKind || kind == NamespaceKind || kind == ProjectKind ||
+			kind == PersistentVolumeKind || kind == StorageClassKind || kind == IngressClassKind, nil
+	}
+
+	mapping, err := source.mapper.RESTMapping(kind.GroupKind(), kind.Version)
+	if err != nil {
+		return nil, false, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		return func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return source.dynamicClient.Resource(mapping.Resource).List(context.TODO(), options)
+		}, true, nil
+	}
+	return func(namespace string, options metav1.ListOptions) (runtime.Object, error) {
+		return source.dynamicClient.Resource(mapping.Resource).Namespace(namespace).List(context.TODO(), options)
+	}, false, nil
+}
@@ -0,0 +1,271 @@
+package source
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	authv1T "github.com/openshift/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// NewManifestSource builds a MemorySource from the manifests exported in the given directory
+// or tarball (.tar, .tar.gz or .tgz), like the output of `oc get -o yaml` or a must-gather archive
+func NewManifestSource(path string) (*MemorySource, error) {
+	manifests, err := readManifests(path)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Read %d manifests from %s", len(manifests), path)
+
+	source, err := NewMemorySource()
+	if err != nil {
+		return nil, err
+	}
+	for i := range manifests {
+		err = source.Add(&manifests[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Namespaces are rarely exported together with their resources, so the missing ones are derived from the resources
+	namespaces, err := source.List(NamespaceKind, "")
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		exported = append(exported, namespace.(*corev1.Namespace).Name)
+	}
+	for _, name := range source.Namespaces() {
+		if !contains(exported, name) {
+			namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			namespace.SetGroupVersionKind(NamespaceKind)
+			err = source.Add(&namespace)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return source, nil
+}
+
+// readManifests loads all the YAML and JSON manifests from the given directory or tarball (.tar, .tar.gz or .tgz)
+func readManifests(path string) ([]unstructured.Unstructured, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return readManifestsDir(path)
+	}
+	return readManifestsTarball(path)
+}
+
+func readManifestsDir(dir string) ([]unstructured.Unstructured, error) {
+	manifests := make([]unstructured.Unstructured, 0)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isManifestFile(path) {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		fileManifests, err := decodeManifests(file, path)
+		if err != nil {
+			return fmt.Errorf("cannot decode %s: %v", path, err)
+		}
+		logger.Debugf("Read %d manifests from %s", len(fileManifests), path)
+		manifests = append(manifests, fileManifests...)
+		return nil
+	})
+	return manifests, err
+}
+
+func readManifestsTarball(path string) ([]unstructured.Unstructured, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".tgz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	manifests := make([]unstructured.Unstructured, 0)
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || !isManifestFile(header.Name) {
+			continue
+		}
+		fileManifests, err := decodeManifests(tarReader, header.Name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode %s in %s: %v", header.Name, path, err)
+		}
+		logger.Debugf("Read %d manifests from %s in %s", len(fileManifests), header.Name, path)
+		manifests = append(manifests, fileManifests...)
+	}
+	return manifests, nil
+}
+
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// decodeManifests decodes all the documents of a multi-document YAML or JSON stream, expanding the List kinds.
+// The documents that are not Kubernetes objects, like a kustomization.yaml or the metadata of a must-gather,
+// are skipped with a warning
+func decodeManifests(reader io.Reader, name string) ([]unstructured.Unstructured, error) {
+	manifests := make([]unstructured.Unstructured, 0)
+	yamlReader := yaml.NewYAMLReader(bufio.NewReader(reader))
+	for {
+		document, err := yamlReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+		json, err := yaml.ToJSON(document)
+		if err != nil {
+			logger.Warnf("Skipped invalid document in %s: %v", name, err)
+			continue
+		}
+		if bytes.Equal(bytes.TrimSpace(json), []byte("null")) {
+			continue
+		}
+
+		manifest := unstructured.Unstructured{}
+		err = manifest.UnmarshalJSON(json)
+		if err != nil {
+			logger.Warnf("Skipped document in %s that is not a Kubernetes object: %v", name, err)
+			continue
+		}
+		if manifest.IsList() {
+			list, err := manifest.ToList()
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, list.Items...)
+		} else {
+			manifests = append(manifests, manifest)
+		}
+	}
+	return manifests, nil
+}
+
+// typedManifest converts the manifest to the matching typed object, or returns it unchanged if the kind has no typed object.
+// Returns nil for the RBAC kinds that are not modelled
+func typedManifest(manifest unstructured.Unstructured) (runtime.Object, error) {
+	gvk := manifest.GroupVersionKind()
+	if gvk.Group == rbacv1.GroupName {
+		// RBAC bindings are modelled with the OpenShift authorization API, as returned by the live cluster
+		return authorizationBinding(manifest)
+	}
+	if !Scheme.Recognizes(gvk) {
+		// Kept as is for the custom resources
+		return &manifest, nil
+	}
+
+	object, err := Scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(manifest.Object, object)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %s %s/%s: %v", gvk.Kind, manifest.GetNamespace(), manifest.GetName(), err)
+	}
+	object.GetObjectKind().SetGroupVersionKind(gvk)
+	return object, nil
+}
+
+func authorizationBinding(manifest unstructured.Unstructured) (runtime.Object, error) {
+	switch manifest.GetKind() {
+	case "RoleBinding":
+		roleBinding := rbacv1.RoleBinding{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(manifest.Object, &roleBinding)
+		if err != nil {
+			return nil, err
+		}
+		binding := authv1T.RoleBinding{ObjectMeta: roleBinding.ObjectMeta}
+		binding.SetGroupVersionKind(authv1T.GroupVersion.WithKind("RoleBinding"))
+		binding.RoleRef = corev1.ObjectReference{Kind: roleBinding.RoleRef.Kind, Name: roleBinding.RoleRef.Name}
+		binding.Subjects, binding.UserNames, binding.GroupNames = authorizationSubjects(roleBinding.Subjects, roleBinding.Namespace)
+		return &binding, nil
+	case "ClusterRoleBinding":
+		clusterRoleBinding := rbacv1.ClusterRoleBinding{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(manifest.Object, &clusterRoleBinding)
+		if err != nil {
+			return nil, err
+		}
+		binding := authv1T.ClusterRoleBinding{ObjectMeta: clusterRoleBinding.ObjectMeta}
+		binding.SetGroupVersionKind(authv1T.GroupVersion.WithKind("ClusterRoleBinding"))
+		binding.RoleRef = corev1.ObjectReference{Kind: clusterRoleBinding.RoleRef.Kind, Name: clusterRoleBinding.RoleRef.Name}
+		binding.Subjects, binding.UserNames, binding.GroupNames = authorizationSubjects(clusterRoleBinding.Subjects, "")
+		return &binding, nil
+	}
+	logger.Debugf("Skipped unsupported manifest of kind %s", manifest.GroupVersionKind())
+	return nil, nil
+}
+
+func authorizationSubjects(subjects []rbacv1.Subject, namespace string) ([]corev1.ObjectReference, authv1T.OptionalNames, authv1T.OptionalNames) {
+	references := make([]corev1.ObjectReference, 0, len(subjects))
+	userNames := authv1T.OptionalNames{}
+	groupNames := authv1T.OptionalNames{}
+	for _, subject := range subjects {
+		subjectNamespace := subject.Namespace
+		if subjectNamespace == "" && subject.Kind == rbacv1.ServiceAccountKind {
+			subjectNamespace = namespace
+		}
+		references = append(references, corev1.ObjectReference{Kind: subject.Kind, Name: subject.Name, Namespace: subjectNamespace})
+		switch subject.Kind {
+		case rbacv1.ServiceAccountKind:
+			userNames = append(userNames, fmt.Sprintf("system:serviceaccount:%s:%s", subjectNamespace, subject.Name))
+		case rbacv1.UserKind:
+			userNames = append(userNames, subject.Name)
+		case rbacv1.GroupKind:
+			groupNames = append(groupNames, subject.Name)
+		}
+	}
+	return references, userNames, groupNames
+}
@@ -0,0 +1,145 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	authv1T "github.com/openshift/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func namesOf(manifests []unstructured.Unstructured) []string {
+	names := make([]string, 0, len(manifests))
+	for _, manifest := range manifests {
+		names = append(names, manifest.GetKind()+"/"+manifest.GetName())
+	}
+	return names
+}
+
+func TestDecodeManifests(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     []string
+	}{
+		{"single document", `
+apiVersion: v1
+kind: Pod
+metadata: {name: api}
+`, []string{"Pod/api"}},
+		{"multiple documents with empty ones", `
+---
+apiVersion: v1
+kind: Pod
+metadata: {name: api}
+---
+---
+apiVersion: v1
+kind: Service
+metadata: {name: api}
+`, []string{"Pod/api", "Service/api"}},
+		{"List kind", `
+apiVersion: v1
+kind: List
+items:
+- {apiVersion: v1, kind: Pod, metadata: {name: api}}
+- {apiVersion: v1, kind: Pod, metadata: {name: web}}
+`, []string{"Pod/api", "Pod/web"}},
+		{"JSON", `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "settings"}}`, []string{"ConfigMap/settings"}},
+		{"null document", `
+apiVersion: v1
+kind: Pod
+metadata: {name: api}
+---
+null
+`, []string{"Pod/api"}},
+		{"kustomization skipped", `
+resources:
+- deployment.yaml
+`, []string{}},
+		{"not an object skipped", `
+- api
+- web
+---
+apiVersion: v1
+kind: Pod
+metadata: {name: api}
+`, []string{"Pod/api"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifests, err := decodeManifests(strings.NewReader(test.document), test.name)
+			if err != nil {
+				t.Fatalf("decodeManifests() error = %v", err)
+			}
+			if got := namesOf(manifests); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("decodeManifests() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewManifestSource(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"ns1/pods.yaml": `
+apiVersion: v1
+kind: Pod
+metadata: {name: api, namespace: ns1}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata: {name: edit, namespace: ns1}
+roleRef: {apiGroup: rbac.authorization.k8s.io, kind: ClusterRole, name: edit}
+subjects: [{kind: ServiceAccount, name: builder}]
+`,
+		"ns2/widgets.json":   `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "w", "namespace": "ns2"}}`,
+		"kustomization.yaml": "resources: [ns1/pods.yaml]\n",
+		"README.md":          "# not a manifest\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	source, err := NewManifestSource(dir)
+	if err != nil {
+		t.Fatalf("NewManifestSource() error = %v", err)
+	}
+	if got, want := source.Namespaces(), []string{"ns1", "ns2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Namespaces() = %v, want %v", got, want)
+	}
+	pods, err := source.List(PodKind, "ns1")
+	if err != nil || len(pods) != 1 {
+		t.Fatalf("List(Pod) = %v, %v", pods, err)
+	}
+	if _, ok := pods[0].(*corev1.Pod); !ok {
+		t.Errorf("List(Pod) returned %T instead of a typed Pod", pods[0])
+	}
+	roleBindings, err := source.List(RoleBindingKind, "ns1")
+	if err != nil || len(roleBindings) != 1 {
+		t.Fatalf("List(RoleBinding) = %v, %v", roleBindings, err)
+	}
+	roleBinding := roleBindings[0].(*authv1T.RoleBinding)
+	if got, want := []string(roleBinding.UserNames), []string{"system:serviceaccount:ns1:builder"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RoleBinding UserNames = %v, want %v", got, want)
+	}
+	namespaces, err := source.List(NamespaceKind, "")
+	if err != nil || len(namespaces) != 2 {
+		t.Errorf("List(Namespace) = %v, %v, want the namespaces derived from the resources", namespaces, err)
+	}
+	widgets, err := source.List(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, "ns2")
+	if err != nil || len(widgets) != 1 {
+		t.Errorf("List(Widget) = %v, %v", widgets, err)
+	}
+}
@@ -0,0 +1,197 @@
+package source
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MemorySource lists the resources from a set of typed objects held in memory
+type MemorySource struct {
+	lock       sync.RWMutex
+	objects    map[schema.GroupVersionKind][]runtime.Object
+	namespaces []string
+	// The stored kinds that were listed, and the ones that were converted to another version
+	usageLock sync.Mutex
+	listed    map[schema.GroupVersionKind]bool
+	converted map[schema.GroupVersionKind]bool
+}
+
+func NewMemorySource(objects ...runtime.Object) (*MemorySource, error) {
+	source := MemorySource{objects: make(map[schema.GroupVersionKind][]runtime.Object),
+		listed: make(map[schema.GroupVersionKind]bool), converted: make(map[schema.GroupVersionKind]bool)}
+	for _, object := range objects {
+		err := source.Add(object)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &source, nil
+}
+
+// Add stores the given object. The kind is taken from the object itself or, when missing, from the Scheme.
+// Unstructured objects of a typed kind are converted to their typed object, as listed by the builder
+func (source *MemorySource) Add(object runtime.Object) error {
+	if manifest, ok := object.(*unstructured.Unstructured); ok {
+		typed, err := typedManifest(*manifest)
+		if err != nil {
+			return err
+		}
+		if typed == nil {
+			return nil
+		}
+		object = typed
+	}
+	kind := object.GetObjectKind().GroupVersionKind()
+	if kind.Empty() {
+		kinds, _, err := Scheme.ObjectKinds(object)
+		if err != nil {
+			return err
+		}
+		kind = kinds[0]
+	}
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return fmt.Errorf("cannot add object of kind %s: %v", kind, err)
+	}
+
+	source.lock.Lock()
+	defer source.lock.Unlock()
+	source.objects[kind] = append(source.objects[kind], object)
+	if namespace := accessor.GetNamespace(); namespace != "" && !contains(source.namespaces, namespace) {
+		source.namespaces = append(source.namespaces, namespace)
+	}
+	return nil
+}
+
+func (source *MemorySource) List(kind schema.GroupVersionKind, namespace string) ([]runtime.Object, error) {
+	source.lock.RLock()
+	defer source.lock.RUnlock()
+	objects := make([]runtime.Object, 0)
+	for _, object := range source.objectsOf(kind) {
+		accessor, err := meta.Accessor(object)
+		if err != nil {
+			return nil, err
+		}
+		if namespace == "" || accessor.GetNamespace() == namespace {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+// objectsOf returns the objects of the given kind, of any version when the version is empty. The objects stored at
+// another version are converted to the given one, like the batch/v1beta1 CronJobs exported from an older cluster
+func (source *MemorySource) objectsOf(kind schema.GroupVersionKind) []runtime.Object {
+	storedKinds := make([]schema.GroupVersionKind, 0)
+	for storedKind := range source.objects {
+		if storedKind.GroupKind() == kind.GroupKind() {
+			storedKinds = append(storedKinds, storedKind)
+		}
+	}
+	// The objects at the given version come first, then the other versions in a stable order
+	sort.Slice(storedKinds, func(i, j int) bool {
+		if (storedKinds[i].Version == kind.Version) != (storedKinds[j].Version == kind.Version) {
+			return storedKinds[i].Version == kind.Version
+		}
+		return storedKinds[i].Version < storedKinds[j].Version
+	})
+
+	objects := make([]runtime.Object, 0)
+	for _, storedKind := range storedKinds {
+		source.markListed(storedKind, kind)
+		if kind.Version == "" || storedKind.Version == kind.Version {
+			objects = append(objects, source.objects[storedKind]...)
+			continue
+		}
+		for _, object := range source.objects[storedKind] {
+			converted, err := convertVersion(object, kind)
+			if err != nil {
+				logger.Warnf("Skipped %s in %s that cannot be converted to %s: %v", storedKind.Kind, storedKind.GroupVersion(),
+					kind.GroupVersion(), err)
+				continue
+			}
+			if converted != nil {
+				objects = append(objects, converted)
+			}
+		}
+	}
+	return objects
+}
+
+func (source *MemorySource) markListed(storedKind schema.GroupVersionKind, kind schema.GroupVersionKind) {
+	source.usageLock.Lock()
+	defer source.usageLock.Unlock()
+	source.listed[storedKind] = true
+	if kind.Version != "" && storedKind.Version != kind.Version && !source.converted[storedKind] {
+		source.converted[storedKind] = true
+		logger.Warnf("Converted %s from %s to %s, the fields that differ between the versions may be lost",
+			storedKind.Kind, storedKind.GroupVersion(), kind.GroupVersion())
+	}
+}
+
+// convertVersion converts the object to the given version of its kind, copying the fields with the same name
+func convertVersion(object runtime.Object, kind schema.GroupVersionKind) (runtime.Object, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+	manifest := unstructured.Unstructured{Object: content}
+	manifest.SetGroupVersionKind(kind)
+	return typedManifest(manifest)
+}
+
+// Unlisted returns the number of stored objects of each kind that was never listed, at any version
+func (source *MemorySource) Unlisted() map[schema.GroupVersionKind]int {
+	source.lock.RLock()
+	defer source.lock.RUnlock()
+	source.usageLock.Lock()
+	defer source.usageLock.Unlock()
+	unlisted := make(map[schema.GroupVersionKind]int)
+	for kind, objects := range source.objects {
+		if !source.listed[kind] {
+			unlisted[kind] = len(objects)
+		}
+	}
+	return unlisted
+}
+
+func (source *MemorySource) Get(kind schema.GroupVersionKind, namespace string, name string) (runtime.Object, error) {
+	objects, err := source.List(kind, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range objects {
+		accessor, err := meta.Accessor(object)
+		if err != nil {
+			return nil, err
+		}
+		// Cluster-scoped objects have no namespace
+		if accessor.GetName() == name && (accessor.GetNamespace() == namespace || accessor.GetNamespace() == "") {
+			return object, nil
+		}
+	}
+	return nil, notFound(kind, name)
+}
+
+// Namespaces returns the namespaces of the stored objects, in order of appearance
+func (source *MemorySource) Namespaces() []string {
+	source.lock.RLock()
+	defer source.lock.RUnlock()
+	return append([]string{}, source.namespaces...)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package source
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestMemorySourceAdd(t *testing.T) {
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1", "kind": "Deployment",
+		"metadata": map[string]interface{}{"name": "api", "namespace": "ns1"},
+		"spec":     map[string]interface{}{"replicas": int64(2)},
+	}}
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1", "kind": "Widget",
+		"metadata": map[string]interface{}{"name": "w", "namespace": "ns1"},
+	}}
+	source, err := NewMemorySource(deployment, widget)
+	if err != nil {
+		t.Fatalf("NewMemorySource() error = %v", err)
+	}
+
+	deployments, err := source.List(DeploymentKind, "ns1")
+	if err != nil || len(deployments) != 1 {
+		t.Fatalf("List(Deployment) = %v, %v", deployments, err)
+	}
+	typed, ok := deployments[0].(*appsv1.Deployment)
+	if !ok {
+		t.Fatalf("List(Deployment) returned %T instead of a typed Deployment", deployments[0])
+	}
+	if typed.Spec.Replicas == nil || *typed.Spec.Replicas != 2 {
+		t.Errorf("Deployment replicas = %v, want 2", typed.Spec.Replicas)
+	}
+	widgets, err := source.List(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, "ns1")
+	if err != nil || len(widgets) != 1 {
+		t.Fatalf("List(Widget) = %v, %v", widgets, err)
+	}
+	if _, ok := widgets[0].(*unstructured.Unstructured); !ok {
+		t.Errorf("List(Widget) returned %T instead of an unstructured object", widgets[0])
+	}
+}
+
+func TestMemorySourceVersions(t *testing.T) {
+	cronJob := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "batch/v1beta1", "kind": "CronJob",
+		"metadata": map[string]interface{}{"name": "backup", "namespace": "ns1"},
+		"spec":     map[string]interface{}{"schedule": "0 * * * *"},
+	}}
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1", "kind": "Widget",
+		"metadata": map[string]interface{}{"name": "w", "namespace": "ns1"},
+	}}
+	source, err := NewMemorySource(cronJob, widget)
+	if err != nil {
+		t.Fatalf("NewMemorySource() error = %v", err)
+	}
+
+	cronJobs, err := source.List(CronJobKind, "ns1")
+	if err != nil || len(cronJobs) != 1 {
+		t.Fatalf("List(CronJob) = %v, %v", cronJobs, err)
+	}
+	typed, ok := cronJobs[0].(*batchv1.CronJob)
+	if !ok {
+		t.Fatalf("List(CronJob) returned %T instead of a batch/v1 CronJob", cronJobs[0])
+	}
+	if typed.Spec.Schedule != "0 * * * *" {
+		t.Errorf("CronJob schedule = %q, want the exported one", typed.Spec.Schedule)
+	}
+	widgetKind := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	if got, want := source.Unlisted(), map[schema.GroupVersionKind]int{widgetKind: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unlisted() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+package source
+
+import (
+	"strings"
+
+	appsv1T "github.com/openshift/api/apps/v1"
+	authv1T "github.com/openshift/api/authorization/v1"
+	buildv1T "github.com/openshift/api/build/v1"
+	imagev1T "github.com/openshift/api/image/v1"
+	projectv1T "github.com/openshift/api/project/v1"
+	routev1T "github.com/openshift/api/route/v1"
+	appsscheme "github.com/openshift/client-go/apps/clientset/versioned/scheme"
+	authscheme "github.com/openshift/client-go/authorization/clientset/versioned/scheme"
+	buildscheme "github.com/openshift/client-go/build/clientset/versioned/scheme"
+	imagescheme "github.com/openshift/client-go/image/clientset/versioned/scheme"
+	projectscheme "github.com/openshift/client-go/project/clientset/versioned/scheme"
+	routescheme "github.com/openshift/client-go/route/clientset/versioned/scheme"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	eventingscheme "knative.dev/eventing/pkg/client/clientset/versioned/scheme"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	servingscheme "knative.dev/serving/pkg/client/clientset/versioned/scheme"
+)
+
+// Source provides the resources used to build the topology model.
+// Implementations must be safe for concurrent use
+type Source interface {
+	// List returns the typed objects of the given kind in the given namespace.
+	// Cluster-scoped kinds are listed with an empty namespace, and an empty version selects the version preferred by the cluster
+	List(kind schema.GroupVersionKind, namespace string) ([]runtime.Object, error)
+	// Get returns the typed object of the given kind and name, or a NotFound error
+	Get(kind schema.GroupVersionKind, namespace string, name string) (runtime.Object, error)
+}
+
+// The kinds collected by the builder
+var (
+	RouteKind        = routev1T.GroupVersion.WithKind("Route")
+	IngressKind      = networkingv1.SchemeGroupVersion.WithKind("Ingress")
+	IngressClassKind = networkingv1.SchemeGroupVersion.WithKind("IngressClass")
+	// The kinds without a typed clientset, like the Gateway API ones, are listed with the dynamic client, in the
+	// version preferred by the cluster
+	GatewayKind               = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Kind: "Gateway"}
+	HTTPRouteKind             = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute"}
+	GatewayClassKind          = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Kind: "GatewayClass"}
+	PodMetricsKind            = schema.GroupVersionKind{Group: "metrics.k8s.io", Kind: "PodMetrics"}
+	KafkaChannelKind          = schema.GroupVersionKind{Group: "messaging.knative.dev", Kind: "KafkaChannel"}
+	NetworkPolicyKind         = networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy")
+	EndpointsKind             = corev1.SchemeGroupVersion.WithKind("Endpoints")
+	EndpointSliceKind         = discoveryv1.SchemeGroupVersion.WithKind("EndpointSlice")
+	ServiceKind               = corev1.SchemeGroupVersion.WithKind("Service")
+	DeploymentKind            = appsv1.SchemeGroupVersion.WithKind("Deployment")
+	StatefulSetKind           = appsv1.SchemeGroupVersion.WithKind("StatefulSet")
+	DeploymentConfigKind      = appsv1T.GroupVersion.WithKind("DeploymentConfig")
+	DaemonSetKind             = appsv1.SchemeGroupVersion.WithKind("DaemonSet")
+	JobKind                   = batchv1.SchemeGroupVersion.WithKind("Job")
+	CronJobKind               = batchv1.SchemeGroupVersion.WithKind("CronJob")
+	ReplicaSetKind            = appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	ReplicationControllerKind = corev1.SchemeGroupVersion.WithKind("ReplicationController")
+	PodKind                   = corev1.SchemeGroupVersion.WithKind("Pod")
+	ConfigMapKind             = corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	SecretKind                = corev1.SchemeGroupVersion.WithKind("Secret")
+	PersistentVolumeClaimKind = corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim")
+	PersistentVolumeKind      = corev1.SchemeGroupVersion.WithKind("PersistentVolume")
+	StorageClassKind          = storagev1.SchemeGroupVersion.WithKind("StorageClass")
+	ServiceAccountKind        = corev1.SchemeGroupVersion.WithKind("ServiceAccount")
+	EventKind                 = corev1.SchemeGroupVersion.WithKind("Event")
+	ImageStreamKind           = imagev1T.GroupVersion.WithKind("ImageStream")
+	BuildConfigKind           = buildv1T.GroupVersion.WithKind("BuildConfig")
+	BuildKind                 = buildv1T.GroupVersion.WithKind("Build")
+	RoleBindingKind           = authv1T.GroupVersion.WithKind("RoleBinding")
+	ClusterRoleBindingKind    = authv1T.GroupVersion.WithKind("ClusterRoleBinding")
+	KnativeServiceKind        = servingv1.SchemeGroupVersion.WithKind("Service")
+	ConfigurationKind         = servingv1.SchemeGroupVersion.WithKind("Configuration")
+	RevisionKind              = servingv1.SchemeGroupVersion.WithKind("Revision")
+	KnativeRouteKind          = servingv1.SchemeGroupVersion.WithKind("Route")
+	SinkBindingKind           = sourcesv1.SchemeGroupVersion.WithKind("SinkBinding")
+	PingSourceKind            = sourcesv1.SchemeGroupVersion.WithKind("PingSource")
+	ApiServerSourceKind       = sourcesv1.SchemeGroupVersion.WithKind("ApiServerSource")
+	ContainerSourceKind       = sourcesv1.SchemeGroupVersion.WithKind("ContainerSource")
+	BrokerKind                = eventingv1.SchemeGroupVersion.WithKind("Broker")
+	TriggerKind               = eventingv1.SchemeGroupVersion.WithKind("Trigger")
+	ChannelKind               = messagingv1.SchemeGroupVersion.WithKind("Channel")
+	InMemoryChannelKind       = messagingv1.SchemeGroupVersion.WithKind("InMemoryChannel")
+	SubscriptionKind          = messagingv1.SchemeGroupVersion.WithKind("Subscription")
+	SequenceKind              = flowsv1.SchemeGroupVersion.WithKind("Sequence")
+	ParallelKind              = flowsv1.SchemeGroupVersion.WithKind("Parallel")
+	NamespaceKind             = corev1.SchemeGroupVersion.WithKind("Namespace")
+	ProjectKind               = projectv1T.GroupVersion.WithKind("Project")
+
+	// The CustomResourceDefinitions are listed to discover the kinds of the Knative event sources
+	CustomResourceDefinitionKind = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+)
+
+func notFound(kind schema.GroupVersionKind, name string) error {
+	return errors.NewNotFound(schema.GroupResource{Group: kind.Group, Resource: strings.ToLower(kind.Kind)}, name)
+}
+
+// Scheme registers the types of all the collected kinds
+var Scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(kubescheme.AddToScheme(Scheme))
+	utilruntime.Must(appsscheme.AddToScheme(Scheme))
+	utilruntime.Must(authscheme.AddToScheme(Scheme))
+	utilruntime.Must(routescheme.AddToScheme(Scheme))
+	utilruntime.Must(projectscheme.AddToScheme(Scheme))
+	utilruntime.Must(imagescheme.AddToScheme(Scheme))
+	utilruntime.Must(buildscheme.AddToScheme(Scheme))
+	utilruntime.Must(eventingscheme.AddToScheme(Scheme))
+	utilruntime.Must(servingscheme.AddToScheme(Scheme))
+}
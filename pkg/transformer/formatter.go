@@ -1,21 +1,72 @@
 package transformer
 
 import (
+	"fmt"
+
 	"github.com/dmartinol/openshift-topology-exporter/pkg/config"
 	"github.com/dmartinol/openshift-topology-exporter/pkg/model"
 )
 
 type Formatter interface {
 	Init()
-	AddNamespace(name string, resources []model.Resource, connections []model.Connection)
+	AddNamespace(name string, resources []model.Resource, connections []model.Connection, events model.Events, warnings []string)
+	// AddClusterScoped draws the cluster-scoped resources once, outside of the namespaces, with the warnings
+	// raised while collecting them
+	AddClusterScoped(resources []model.Resource, connections []model.Connection, warnings []string)
 	BuildOutput() (string, error)
 }
 
 func NewFormatterForConfig(config config.ExporterConfig) Formatter {
-	if config.FormatterClass == "graphviz" {
-		return NewGraphVizFormatter()
-	} else if config.FormatterClass == "mermaid" {
-		return NewMermaidFormatter()
+	if config.FormatterClass == "mermaid" {
+		formatter := NewMermaidFormatter()
+		formatter.drawWarnings = config.DrawWarnings
+		formatter.colorByUtilization = config.Metrics.ColorByUtilization
+		return formatter
+	}
+	formatter := NewGraphVizFormatter()
+	formatter.drawWarnings = config.DrawWarnings
+	formatter.colorByUtilization = config.Metrics.ColorByUtilization
+	return formatter
+}
+
+// colorOf returns the status color of the resource or, when coloring by utilization, the color of the utilization
+// of its CPU and memory requests
+func colorOf(resource model.Resource, colorByUtilization bool) (string, bool) {
+	if !colorByUtilization {
+		return resource.StatusColor()
+	}
+	if measured, ok := resource.(model.Measured); ok {
+		if utilization, ok := measured.Utilization(); ok {
+			return utilization.Color()
+		}
+	}
+	return "", false
+}
+
+type legendEntry struct {
+	label string
+	color string
+}
+
+// utilizationLegend returns the legend of the colors by utilization
+func utilizationLegend() []legendEntry {
+	return []legendEntry{
+		{label: fmt.Sprintf("Under %d%% of requests", int(model.HighUtilization*100)), color: model.RunningColor},
+		{label: fmt.Sprintf("Over %d%% of requests", int(model.HighUtilization*100)), color: model.WarningColor},
+		{label: "Over requests", color: model.FailedColor},
+	}
+}
+
+// eventsBadge returns the count of Events drawn next to a resource
+func eventsBadge(events []model.Event) string {
+	return fmt.Sprintf("⚠ %d", len(events))
+}
+
+// latestEventMessages returns the messages of the latest Events, the latest first
+func latestEventMessages(events []model.Event) []string {
+	messages := make([]string, 0, model.MaxEventMessages)
+	for i := 0; i < len(events) && i < model.MaxEventMessages; i++ {
+		messages = append(messages, events[i].String())
 	}
-	return NewGraphVizFormatter()
+	return messages
 }
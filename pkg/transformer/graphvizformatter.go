@@ -10,8 +10,10 @@ import (
 )
 
 type GraphVizFormatter struct {
-	diagram      strings.Builder
-	clusterCount int
+	diagram            strings.Builder
+	clusterCount       int
+	drawWarnings       bool
+	colorByUtilization bool
 }
 
 func NewGraphVizFormatter() *GraphVizFormatter {
@@ -31,9 +33,22 @@ func (formatter *GraphVizFormatter) legend() {
 	formatter.diagram.WriteString("subgraph legend {\n")
 	formatter.diagram.WriteString("legend [\n")
 	formatter.diagram.WriteString("label=<<TABLE border=\"0\" cellspacing=\"2\" cellpadding=\"0\">\n")
-	formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Completed</TD></TR>\n", model.CompletedColor))
-	formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Running</TD></TR>\n", model.RunningColor))
-	formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Failed</TD></TR>\n", model.FailedColor))
+	if formatter.colorByUtilization {
+		for _, entry := range utilizationLegend() {
+			formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">%s</TD></TR>\n", entry.color, entry.label))
+		}
+	} else {
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Completed</TD></TR>\n", model.CompletedColor))
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Running</TD></TR>\n", model.RunningColor))
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Progressing</TD></TR>\n", model.ProgressingColor))
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Pending</TD></TR>\n", model.PendingColor))
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Degraded</TD></TR>\n", model.WarningColor))
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">BackOff</TD></TR>\n", model.BackOffColor))
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Failed</TD></TR>\n", model.FailedColor))
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Terminating</TD></TR>\n", model.TerminatingColor))
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Evicted</TD></TR>\n", model.EvictedColor))
+		formatter.diagram.WriteString(fmt.Sprintf("<TR><TD border=\"0\" bgcolor=\"%s\">Missing</TD></TR>\n", model.MissingColor))
+	}
 	formatter.diagram.WriteString("<TR><TD>Legend</TD></TR>\n")
 	formatter.diagram.WriteString("</TABLE>>];\n")
 	formatter.diagram.WriteString("}\n")
@@ -48,28 +63,63 @@ func (formatter *GraphVizFormatter) initNamespace(name string) {
 	formatter.clusterCount++
 }
 
-func (formatter *GraphVizFormatter) AddNamespace(name string, resources []model.Resource, connections []model.Connection) {
+func (formatter *GraphVizFormatter) AddNamespace(name string, resources []model.Resource, connections []model.Connection,
+	events model.Events, warnings []string) {
 	formatter.initNamespace(name)
+	formatter.addWarnings(name, warnings)
+	formatter.addResources(resources, events)
+	formatter.addConnections(connections)
+	formatter.diagram.WriteString("\n}")
+}
+
+// AddClusterScoped draws the cluster-scoped resources in their own cluster, and their connections outside of it
+// so that the connected namespaced resources are not moved in the cluster
+func (formatter *GraphVizFormatter) AddClusterScoped(resources []model.Resource, connections []model.Connection, warnings []string) {
+	formatter.initNamespace("Cluster-scoped")
+	formatter.addWarnings("Cluster-scoped", warnings)
+	formatter.addResources(resources, model.Events{})
+	formatter.diagram.WriteString("\n}\n")
+	formatter.addConnections(connections)
+}
+
+// addWarnings draws a note with the warnings of the namespace, when enabled
+func (formatter *GraphVizFormatter) addWarnings(name string, warnings []string) {
+	if !formatter.drawWarnings || len(warnings) == 0 {
+		return
+	}
+	formatter.diagram.WriteString(fmt.Sprintf("\"warnings %s\" [ shape=note, style=filled, color=\"%s\", label=\"%s\\l\" ];\n",
+		name, model.WarningColor, escapeLabel(strings.Join(warnings, "\\l"))))
+}
+
+// addResources draws the resources with their status color, and the count of their Events as an external label with
+// the latest messages as tooltip
+func (formatter *GraphVizFormatter) addResources(resources []model.Resource, events model.Events) {
 	for _, resource := range resources {
-		color, hasStatusColor := resource.StatusColor()
+		options := fmt.Sprintf("class=\"%s\", label=\"%s\", image=\"%s\", labelloc=b",
+			resource.Kind(), resource.Label(), resource.Icon())
+		color, hasStatusColor := colorOf(resource, formatter.colorByUtilization)
 		if hasStatusColor {
-			formatter.diagram.WriteString(fmt.Sprintf("\"%s\" [ class=\"%s\", label=\"%s\", image=\"%s\", labelloc=b, color=\"%s\" ];\n",
-				resource.Id(), resource.Kind(), resource.Label(), resource.Icon(), color))
-		} else {
-			formatter.diagram.WriteString(fmt.Sprintf("\"%s\" [ class=\"%s\", label=\"%s\", image=\"%s\", labelloc=b ];\n",
-				resource.Id(), resource.Kind(), resource.Label(), resource.Icon()))
+			options += fmt.Sprintf(", color=\"%s\"", color)
 		}
+		if resourceEvents := events.Of(resource); len(resourceEvents) > 0 {
+			options += fmt.Sprintf(", xlabel=\"%s\", tooltip=\"%s\"", eventsBadge(resourceEvents),
+				escapeLabel(strings.Join(latestEventMessages(resourceEvents), "\\n")))
+		}
+		formatter.diagram.WriteString(fmt.Sprintf("\"%s\" [ %s ];\n", resource.Id(), options))
 	}
+}
 
+func (formatter *GraphVizFormatter) addConnections(connections []model.Connection) {
 	logger.Debugf("Adding %d connections", len(connections))
 	for _, connection := range connections {
 		options := ""
-		if len(connection.Name) != 0 {
-			options = fmt.Sprintf(" [label=\"%s\"]", connection.Name)
+		if connection.IsAlternatePath() {
+			options = fmt.Sprintf(" [label=\"%s\", style=dashed]", escapeLabel(connection.Name))
+		} else if len(connection.Name) != 0 {
+			options = fmt.Sprintf(" [label=\"%s\"]", escapeLabel(connection.Name))
 		}
 		formatter.diagram.WriteString(fmt.Sprintf("\"%s\" -> \"%s\"%s\n", connection.From.Id(), connection.To.Id(), options))
 	}
-	formatter.diagram.WriteString("\n}")
 }
 
 func (formatter *GraphVizFormatter) BuildOutput() (string, error) {
@@ -84,3 +134,7 @@ func (formatter *GraphVizFormatter) BuildOutput() (string, error) {
 	file.WriteString(output)
 	return output, nil
 }
+
+func escapeLabel(label string) string {
+	return strings.ReplaceAll(label, "\"", "\\\"")
+}
@@ -3,6 +3,7 @@ package transformer
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	logger "github.com/dmartinol/openshift-topology-exporter/pkg/log"
@@ -10,7 +11,9 @@ import (
 )
 
 type MermaidFormatter struct {
-	diagram strings.Builder
+	diagram            strings.Builder
+	drawWarnings       bool
+	colorByUtilization bool
 }
 
 func NewMermaidFormatter() *MermaidFormatter {
@@ -27,12 +30,34 @@ func (formatter *MermaidFormatter) Init() {
 
 func (formatter *MermaidFormatter) legend() {
 	formatter.diagram.WriteString("subgraph legend\n")
+	if formatter.colorByUtilization {
+		for i, entry := range utilizationLegend() {
+			formatter.diagram.WriteString(fmt.Sprintf("\tutilization%d[\"%s\"]\n", i, entry.label))
+			formatter.diagram.WriteString(fmt.Sprintf("\tstyle utilization%d fill: %s\n", i, entry.color))
+		}
+		formatter.diagram.WriteString("end\n")
+		return
+	}
 	formatter.diagram.WriteString("\tCompleted\n")
 	formatter.diagram.WriteString("\tRunning\n")
+	formatter.diagram.WriteString("\tProgressing\n")
+	formatter.diagram.WriteString("\tPending\n")
+	formatter.diagram.WriteString("\tDegraded\n")
+	formatter.diagram.WriteString("\tBackOff\n")
 	formatter.diagram.WriteString("\tFailed\n")
+	formatter.diagram.WriteString("\tTerminating\n")
+	formatter.diagram.WriteString("\tEvicted\n")
+	formatter.diagram.WriteString("\tMissing\n")
 	formatter.diagram.WriteString(fmt.Sprintf("\tstyle Completed fill: %s\n", model.CompletedColor))
 	formatter.diagram.WriteString(fmt.Sprintf("\tstyle Running fill: %s\n", model.RunningColor))
+	formatter.diagram.WriteString(fmt.Sprintf("\tstyle Progressing fill: %s\n", model.ProgressingColor))
+	formatter.diagram.WriteString(fmt.Sprintf("\tstyle Pending fill: %s\n", model.PendingColor))
+	formatter.diagram.WriteString(fmt.Sprintf("\tstyle Degraded fill: %s\n", model.WarningColor))
+	formatter.diagram.WriteString(fmt.Sprintf("\tstyle BackOff fill: %s\n", model.BackOffColor))
 	formatter.diagram.WriteString(fmt.Sprintf("\tstyle Failed fill: %s\n", model.FailedColor))
+	formatter.diagram.WriteString(fmt.Sprintf("\tstyle Terminating fill: %s\n", model.TerminatingColor))
+	formatter.diagram.WriteString(fmt.Sprintf("\tstyle Evicted fill: %s\n", model.EvictedColor))
+	formatter.diagram.WriteString(fmt.Sprintf("\tstyle Missing fill: %s\n", model.MissingColor))
 	formatter.diagram.WriteString("end\n")
 }
 
@@ -40,29 +65,85 @@ func (formatter *MermaidFormatter) initNamespace(name string) {
 	formatter.diagram.WriteString(fmt.Sprintf("\nsubgraph %s\n", name))
 }
 
-func (formatter *MermaidFormatter) AddNamespace(name string, resources []model.Resource, connections []model.Connection) {
+func (formatter *MermaidFormatter) AddNamespace(name string, resources []model.Resource, connections []model.Connection,
+	events model.Events, warnings []string) {
 	formatter.initNamespace(name)
+	formatter.addWarnings(name, warnings)
+	formatter.addResources(resources, events)
+	formatter.addEventsFootnote(name, resources, events)
+	formatter.addConnections(connections)
+	formatter.diagram.WriteString("end")
+}
+
+// AddClusterScoped draws the cluster-scoped resources in their own subgraph, and their connections outside of it
+// so that the connected namespaced resources are not moved in the subgraph
+func (formatter *MermaidFormatter) AddClusterScoped(resources []model.Resource, connections []model.Connection, warnings []string) {
+	formatter.initNamespace("Cluster-scoped")
+	formatter.addWarnings("Cluster-scoped", warnings)
+	formatter.addResources(resources, model.Events{})
+	formatter.diagram.WriteString("end\n")
+	formatter.addConnections(connections)
+}
+
+// addWarnings draws a note with the warnings of the namespace, when enabled
+func (formatter *MermaidFormatter) addWarnings(name string, warnings []string) {
+	if !formatter.drawWarnings || len(warnings) == 0 {
+		return
+	}
+	id := normalizeId(fmt.Sprintf("warnings %s", name))
+	formatter.diagram.WriteString(fmt.Sprintf("\t%s[\"%s\"]\n", id, strings.ReplaceAll(strings.Join(warnings, "<br/>"), "\"", "#quot;")))
+	formatter.diagram.WriteString(fmt.Sprintf("\tstyle %s fill:%s\n", id, model.WarningColor))
+}
+
+// addResources draws the resources with their status color and the count of their Events
+func (formatter *MermaidFormatter) addResources(resources []model.Resource, events model.Events) {
 	for _, resource := range resources {
-		formatter.diagram.WriteString(fmt.Sprintf("\t%s(<b>%s</b><br/>%s)\n",
-			normalizeId(resource.Id()), resource.Kind(), resource.Label()))
+		label := resource.Label()
+		if resourceEvents := events.Of(resource); len(resourceEvents) > 0 {
+			label = fmt.Sprintf("%s<br/>%s", label, eventsBadge(resourceEvents))
+		}
+		// Quoted text, as the labels may contain parentheses
+		formatter.diagram.WriteString(fmt.Sprintf("\t%s(\"<b>%s</b><br/>%s\")\n",
+			normalizeId(resource.Id()), resource.Kind(), strings.ReplaceAll(label, "\"", "#quot;")))
 
-		color, hasStatusColor := resource.StatusColor()
+		color, hasStatusColor := colorOf(resource, formatter.colorByUtilization)
 		if hasStatusColor {
 			formatter.diagram.WriteString(fmt.Sprintf("\tstyle %s fill:%s\n", normalizeId(resource.Id()), color))
 		}
 	}
+}
 
+// addEventsFootnote draws a note with the latest Event messages of the resources of the namespace
+func (formatter *MermaidFormatter) addEventsFootnote(name string, resources []model.Resource, events model.Events) {
+	lines := make([]string, 0)
+	for _, resource := range resources {
+		for _, message := range latestEventMessages(events.Of(resource)) {
+			lines = append(lines, fmt.Sprintf("<b>%s</b> %s", resource.Name(), message))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	id := normalizeId(fmt.Sprintf("events %s", name))
+	formatter.diagram.WriteString(fmt.Sprintf("\t%s[\"%s\"]\n", id, strings.ReplaceAll(strings.Join(lines, "<br/>"), "\"", "#quot;")))
+	formatter.diagram.WriteString(fmt.Sprintf("\tstyle %s fill:%s\n", id, model.WarningColor))
+}
+
+func (formatter *MermaidFormatter) addConnections(connections []model.Connection) {
 	logger.Debugf("Adding %d connections", len(connections))
 	for _, connection := range connections {
-		if len(connection.Name) != 0 {
-			formatter.diagram.WriteString(fmt.Sprintf("\t%s -- %s --> %s\n", normalizeId(connection.From.Id()),
-				connection.Name, normalizeId(connection.To.Id())))
+		// Quoted text, as the names may contain semicolons, like the traffic allowed by the NetworkPolicies
+		if connection.IsAlternatePath() {
+			formatter.diagram.WriteString(fmt.Sprintf("\t%s -.->|%s| %s\n", normalizeId(connection.From.Id()),
+				quotedText(connection.Name), normalizeId(connection.To.Id())))
+		} else if len(connection.Name) != 0 {
+			formatter.diagram.WriteString(fmt.Sprintf("\t%s -->|%s| %s\n", normalizeId(connection.From.Id()),
+				quotedText(connection.Name), normalizeId(connection.To.Id())))
 		} else {
 			formatter.diagram.WriteString(fmt.Sprintf("\t%s ----> %s\n", normalizeId(connection.From.Id()),
 				normalizeId(connection.To.Id())))
 		}
 	}
-	formatter.diagram.WriteString("end")
 }
 
 func (formatter *MermaidFormatter) BuildOutput() (string, error) {
@@ -78,6 +159,15 @@ func (formatter *MermaidFormatter) BuildOutput() (string, error) {
 	return output, nil
 }
 
+// invalidIdCharacters are replaced in the ids, like the spaces of the resource ids or the colons and slashes of the
+// URIs and the ImageStreamTags
+var invalidIdCharacters = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// quotedText quotes the text of a label, escaping its quotes
+func quotedText(text string) string {
+	return fmt.Sprintf("\"%s\"", strings.ReplaceAll(text, "\"", "#quot;"))
+}
+
 func normalizeId(id string) string {
-	return strings.ReplaceAll(id, " ", "_")
+	return invalidIdCharacters.ReplaceAllString(id, "_")
 }
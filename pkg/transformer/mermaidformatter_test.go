@@ -0,0 +1,36 @@
+package transformer
+
+import (
+	"testing"
+
+	"github.com/dmartinol/openshift-topology-exporter/pkg/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMermaidConnections(t *testing.T) {
+	from := model.Pod{Delegate: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "fe"}}}
+	to := model.Pod{Delegate: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "be"}}}
+	tests := []struct {
+		name       string
+		connection string
+		want       string
+	}{
+		{"unnamed", "", "\tpod_fe ----> pod_be\n"},
+		{"named", "owns", "\tpod_fe -->|\"owns\"| pod_be\n"},
+		{"traffic", "ingress TCP/8080; egress TCP/5432", "\tpod_fe -->|\"ingress TCP/8080; egress TCP/5432\"| pod_be\n"},
+		{"traffic split", "10%, tag canary", "\tpod_fe -->|\"10%, tag canary\"| pod_be\n"},
+		{"trigger filter", "source=orders, type=created", "\tpod_fe -->|\"source=orders, type=created\"| pod_be\n"},
+		{"quotes", "say \"hello\"", "\tpod_fe -->|\"say #quot;hello#quot;\"| pod_be\n"},
+		{"alternate path", model.DeadLetterConnection, "\tpod_fe -.->|\"dead letter\"| pod_be\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			formatter := NewMermaidFormatter()
+			formatter.addConnections([]model.Connection{{From: from, To: to, Name: test.connection}})
+			if got := formatter.diagram.String(); got != test.want {
+				t.Errorf("addConnections() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -12,10 +12,15 @@ func NewTransformer(formatter Formatter) *Transformer {
 	return &Transformer{formatter: formatter}
 }
 
-func (transformer Transformer) Transform(topologyModel model.TopologyModel) (string, error) {
+func (transformer Transformer) Transform(topologyModel *model.TopologyModel) (string, error) {
 	transformer.formatter.Init()
 	for _, namespace := range topologyModel.AllNamespaces() {
-		transformer.formatter.AddNamespace(namespace.Name(), namespace.AllResources(), namespace.AllConnections())
+		transformer.formatter.AddNamespace(namespace.Name(), namespace.AllResources(), namespace.AllConnections(),
+			namespace.Events(), namespace.Warnings())
+	}
+	clusterScoped := topologyModel.ClusterScoped()
+	if len(clusterScoped.AllResources()) > 0 || len(topologyModel.Warnings()) > 0 {
+		transformer.formatter.AddClusterScoped(clusterScoped.AllResources(), clusterScoped.AllConnections(), topologyModel.Warnings())
 	}
 	return transformer.formatter.BuildOutput()
 }